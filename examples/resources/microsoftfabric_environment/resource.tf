@@ -0,0 +1,30 @@
+resource "microsoftfabric_environment" "example_environment" {
+  workspace_id = microsoftfabric_workspace.example.id
+  display_name = "environment_demo"
+  description  = "Spark environment for batch jobs"
+
+  spark_compute = {
+    instance_pool_name = microsoftfabric_spark_pool.example_pool.name
+    instance_pool_type = "Workspace"
+    driver_cores       = 8
+    driver_memory      = "56g"
+    executor_cores     = 8
+    executor_memory    = "56g"
+    runtime_version    = "1.3"
+
+    dynamic_executor_allocation = {
+      enabled       = true
+      min_executors = 1
+      max_executors = 4
+    }
+
+    spark_properties = {
+      "spark.sql.shuffle.partitions" = "200"
+    }
+  }
+
+  environment_yml_path = "${path.module}/environment.yml"
+  custom_library_paths = [
+    "${path.module}/dist/common_utils-0.1.0-py3-none-any.whl",
+  ]
+}
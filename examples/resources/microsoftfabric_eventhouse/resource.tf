@@ -1,5 +1,10 @@
 resource "microsoftfabric_eventhouse" "example_eventhouse" {
-  workspace_id = microsoftfabric_workspace.example.id
-  display_name = "example_eventhouse_demo"
-  description  = "An example_eventhouse description"
+  workspace_id              = microsoftfabric_workspace.example.id
+  display_name              = "example_eventhouse_demo"
+  description               = "An example_eventhouse description"
+  minimum_consumption_units = 2.25
+}
+
+output "eventhouse_query_uri" {
+  value = microsoftfabric_eventhouse.example_eventhouse.query_service_uri
 }
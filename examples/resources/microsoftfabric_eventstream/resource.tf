@@ -2,4 +2,40 @@ resource "microsoftfabric_eventstream" "example_eventstream" {
   workspace_id = microsoftfabric_workspace.example.id
   name         = "Eventstream_demo"
   description  = "An eventstream description."
-}
\ No newline at end of file
+
+  sources = [
+    {
+      name             = "bicycles"
+      type             = "SampleData"
+      sample_data_type = "Bicycles"
+    }
+  ]
+
+  operators = [
+    {
+      name              = "available_bikes"
+      type              = "Filter"
+      filter_column     = "No_Bikes"
+      filter_operator   = "GreaterThan"
+      filter_value      = "0"
+      filter_value_type = "BigInt"
+    }
+  ]
+
+  destinations = [
+    {
+      name        = "lakehouse"
+      type        = "Lakehouse"
+      item_id     = microsoftfabric_lakehouse.example.id
+      table_name  = "bicycles"
+      input_nodes = ["available_bikes"]
+    },
+    {
+      name          = "eventhouse"
+      type          = "Eventhouse"
+      item_id       = microsoftfabric_kqldatabase.example_kql_database.id
+      database_name = "bicycles_db"
+      table_name    = "bicycles"
+    }
+  ]
+}
@@ -0,0 +1,33 @@
+# Let a consumer group build reports on a shared semantic model without a workspace role.
+resource "microsoftfabric_item_permissions" "model_consumers" {
+  workspace_id = microsoftfabric_workspace.example.id
+  item_id      = microsoftfabric_semantic_model.example_semantic_model.id
+
+  grants = [
+    {
+      principal_id   = "11111111-1111-1111-1111-111111111111"
+      principal_type = "Group"
+      permissions    = ["Read", "Build"]
+    },
+    {
+      principal_id   = "22222222-2222-2222-2222-222222222222"
+      principal_type = "App"
+      permissions    = ["Read"]
+    }
+  ]
+}
+
+# Make the analysts the only principals with direct access to the semantic model.
+resource "microsoftfabric_item_permissions" "model_analysts" {
+  workspace_id = microsoftfabric_workspace.example.id
+  item_id      = microsoftfabric_semantic_model.example_semantic_model.id
+  mode         = "authoritative"
+
+  grants = [
+    {
+      principal_id   = "analyst@contoso.com"
+      principal_type = "User"
+      permissions    = ["Read", "Reshare", "Build"]
+    }
+  ]
+}
@@ -1,9 +1,26 @@
 resource "microsoftfabric_kqldatabase" "example_kql_database" {
+  workspace_id       = microsoftfabric_workspace.example.id
+  display_name       = "example_kql_database_demo"
+  description        = "I am a description."
+  hot_cache_period   = "P30D"
+  soft_delete_period = "P365D"
+  creation_payload = {
+    database_type              = "ReadWrite"
+    parent_eventhouse_items_id = microsoftfabric_eventhouse.example_eventhouse.id
+  }
+}
+
+resource "microsoftfabric_kqldatabase" "example_follower_database" {
   workspace_id = microsoftfabric_workspace.example.id
-  display_name = "example_kql_database_demo"
-  description = "I am a description."
+  display_name = "example_follower_database"
   creation_payload = {
-    database_type = "ReadWrite"
+    database_type              = "ReadOnlyFollowing"
     parent_eventhouse_items_id = microsoftfabric_eventhouse.example_eventhouse.id
+    source_cluster_uri         = "https://mycluster.westeurope.kusto.windows.net"
+    source_database_name       = "SourceDatabase"
   }
-}
\ No newline at end of file
+}
+
+output "kql_database_query_uri" {
+  value = microsoftfabric_kqldatabase.example_kql_database.query_service_uri
+}
@@ -0,0 +1,14 @@
+resource "microsoftfabric_kql_function" "events_in_range" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  kql_database_id = microsoftfabric_kqldatabase.example_kql_database.id
+  name            = "EventsInRange"
+  parameters      = "from:datetime, to:datetime"
+  folder          = "queries"
+  docstring       = "Events between two points in time"
+  body            = <<-KQL
+    Events
+    | where Timestamp between (from .. to)
+  KQL
+
+  depends_on = [microsoftfabric_kql_table.events]
+}
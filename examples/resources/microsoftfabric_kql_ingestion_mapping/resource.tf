@@ -0,0 +1,12 @@
+resource "microsoftfabric_kql_ingestion_mapping" "events_json" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  kql_database_id = microsoftfabric_kqldatabase.example_kql_database.id
+  table_name      = microsoftfabric_kql_table.events.name
+  name            = "EventsJsonMapping"
+  kind            = "json"
+  mapping = jsonencode([
+    { column = "Timestamp", Properties = { Path = "$.ts" } },
+    { column = "DeviceId", Properties = { Path = "$.device" } },
+    { column = "Payload", Properties = { Path = "$" } },
+  ])
+}
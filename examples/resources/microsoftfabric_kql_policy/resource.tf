@@ -0,0 +1,20 @@
+resource "microsoftfabric_kql_policy" "events_retention" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  kql_database_id = microsoftfabric_kqldatabase.example_kql_database.id
+  entity_type     = "table"
+  entity_name     = microsoftfabric_kql_table.events.name
+  policy_type     = "retention"
+  policy = jsonencode({
+    SoftDeletePeriod = "30.00:00:00"
+    Recoverability   = "Enabled"
+  })
+}
+
+resource "microsoftfabric_kql_policy" "events_caching" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  kql_database_id = microsoftfabric_kqldatabase.example_kql_database.id
+  entity_type     = "table"
+  entity_name     = microsoftfabric_kql_table.events.name
+  policy_type     = "caching"
+  policy          = "7d"
+}
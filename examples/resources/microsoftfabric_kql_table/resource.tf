@@ -0,0 +1,13 @@
+resource "microsoftfabric_kql_table" "events" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  kql_database_id = microsoftfabric_kqldatabase.example_kql_database.id
+  name            = "Events"
+  folder          = "raw"
+  docstring       = "Raw device events"
+
+  columns = [
+    { name = "Timestamp", type = "datetime" },
+    { name = "DeviceId", type = "string" },
+    { name = "Payload", type = "dynamic" },
+  ]
+}
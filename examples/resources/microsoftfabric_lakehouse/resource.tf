@@ -2,4 +2,11 @@ resource "microsoftfabric_lakehouse" "example_lakehouse" {
   workspace_id = microsoftfabric_workspace.example.id
   display_name = "lakehouse_demo"
   description  = "An example"
-}
\ No newline at end of file
+}
+
+# Lakehouse that organizes its tables in schemas, e.g. dbo.customers.
+resource "microsoftfabric_lakehouse" "example_schema_lakehouse" {
+  workspace_id   = microsoftfabric_workspace.example.id
+  display_name   = "lakehouse_schemas_demo"
+  enable_schemas = true
+}
@@ -0,0 +1,11 @@
+# Make newly loaded tables and shortcuts visible to the SQL analytics endpoint.
+resource "microsoftfabric_lakehouse_sql_endpoint_refresh" "example" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  lakehouse_id    = microsoftfabric_lakehouse.example_lakehouse.id
+  timeout_minutes = 15
+
+  triggers = {
+    sales_load = microsoftfabric_lakehouse_table.example.operation_id
+    shortcuts  = microsoftfabric_shortcuts.silver_shortcuts.last_updated
+  }
+}
@@ -6,6 +6,10 @@ resource "microsoftfabric_lakehouse_table" "example" {
   path_type     = "File"
   mode          = "Overwrite"
   recursive     = false
+
+  # Delete the table's delta folder when the resource is destroyed.
+  drop_on_destroy = true
+
   format_options = {
     format    = "Csv"
     header    = true
@@ -0,0 +1,14 @@
+resource "microsoftfabric_lakehouse_table_maintenance" "sales" {
+  workspace_id = microsoftfabric_workspace.example.id
+  lakehouse_id = microsoftfabric_lakehouse.example_lakehouse2.id
+  table_name   = microsoftfabric_lakehouse_table.example.table_name
+
+  v_order                 = true
+  z_order_by              = ["customer_id", "order_date"]
+  vacuum_retention_period = "7.01:00:00"
+
+  # Run the maintenance again after every new load of the table.
+  triggers = {
+    load = microsoftfabric_lakehouse_table.example.operation_id
+  }
+}
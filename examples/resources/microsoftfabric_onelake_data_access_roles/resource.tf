@@ -0,0 +1,41 @@
+# Give each consumer group read access to its own tables of a shared lakehouse.
+resource "microsoftfabric_onelake_data_access_roles" "shared" {
+  workspace_id = microsoftfabric_workspace.example.id
+  item_id      = microsoftfabric_lakehouse.example_lakehouse.id
+
+  roles = {
+    # Keep the default behaviour for principals with ReadAll on the lakehouse.
+    DefaultReader = {
+      paths = ["*"]
+      fabric_item_members = [
+        {
+          source_path = "${microsoftfabric_workspace.example.id}/${microsoftfabric_lakehouse.example_lakehouse.id}"
+          item_access = ["ReadAll"]
+        }
+      ]
+    }
+
+    SalesConsumers = {
+      paths = ["Tables/sales", "Tables/customers"]
+      entra_members = [
+        {
+          tenant_id   = "00000000-0000-0000-0000-000000000000"
+          object_id   = "11111111-1111-1111-1111-111111111111"
+          object_type = "Group"
+        }
+      ]
+    }
+
+    FinanceConsumers = {
+      paths   = ["Tables/ledger", "Files/finance"]
+      actions = ["Read"]
+      entra_members = [
+        {
+          tenant_id   = "00000000-0000-0000-0000-000000000000"
+          object_id   = "22222222-2222-2222-2222-222222222222"
+          object_type = "Group"
+        }
+      ]
+    }
+  }
+}
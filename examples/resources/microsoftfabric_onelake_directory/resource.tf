@@ -0,0 +1,7 @@
+# Create a folder for reference data in the Files section of a lakehouse.
+resource "microsoftfabric_onelake_directory" "reference" {
+  workspace_id  = microsoftfabric_workspace.example.id
+  item_id       = microsoftfabric_lakehouse.example_lakehouse.id
+  path          = "Files/ref"
+  force_destroy = true
+}
@@ -0,0 +1,15 @@
+# Seed a reference CSV from a local file.
+resource "microsoftfabric_onelake_file" "countries" {
+  workspace_id = microsoftfabric_workspace.example.id
+  item_id      = microsoftfabric_lakehouse.example_lakehouse.id
+  path         = "${microsoftfabric_onelake_directory.reference.path}/countries.csv"
+  source       = "${path.module}/data/countries.csv"
+}
+
+# Write a small file from inline content.
+resource "microsoftfabric_onelake_file" "readme" {
+  workspace_id = microsoftfabric_workspace.example.id
+  item_id      = microsoftfabric_lakehouse.example_lakehouse.id
+  path         = "Files/ref/README.md"
+  content      = "Reference data managed by Terraform."
+}
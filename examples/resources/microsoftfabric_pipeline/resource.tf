@@ -2,10 +2,18 @@ resource "microsoftfabric_pipeline" "example_pipeline" {
   display_name = "example pipeline"
   description  = "example pipeline"
 
-  workspaces = [
+  stages = [
     {
+      display_name = "Development"
+      description  = "Work in progress"
       workspace_id = microsoftfabric_workspace.example.id
-      stage_order  = 1
+    },
+    {
+      display_name = "Test"
+    },
+    {
+      display_name = "Production"
+      is_public    = true
     }
   ]
-}
\ No newline at end of file
+}
@@ -0,0 +1,25 @@
+resource "microsoftfabric_pipeline_deployment" "deploy_to_test" {
+  pipeline_id     = microsoftfabric_pipeline.example_pipeline.id
+  source_stage_id = microsoftfabric_pipeline.example_pipeline.stages[0].id
+  target_stage_id = microsoftfabric_pipeline.example_pipeline.stages[1].id
+  note            = "Deployed by Terraform"
+
+  triggers = {
+    release = var.release_version
+  }
+}
+
+resource "microsoftfabric_pipeline_deployment" "deploy_report_to_production" {
+  pipeline_id     = microsoftfabric_pipeline.example_pipeline.id
+  source_stage_id = microsoftfabric_pipeline.example_pipeline.stages[1].id
+  target_stage_id = microsoftfabric_pipeline.example_pipeline.stages[2].id
+
+  items = [
+    {
+      item_id   = microsoftfabric_report.example_report.id
+      item_type = "Report"
+    }
+  ]
+
+  depends_on = [microsoftfabric_pipeline_deployment.deploy_to_test]
+}
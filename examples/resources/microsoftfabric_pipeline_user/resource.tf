@@ -0,0 +1,18 @@
+resource "microsoftfabric_pipeline_user" "release_manager" {
+  pipeline_id    = microsoftfabric_pipeline.example_pipeline.id
+  principal_id   = "00000000-0000-0000-0000-000000000000"
+  principal_type = "User"
+}
+
+resource "microsoftfabric_pipeline_user" "release_group" {
+  pipeline_id    = microsoftfabric_pipeline.example_pipeline.id
+  principal_id   = "11111111-1111-1111-1111-111111111111"
+  principal_type = "Group"
+}
+
+resource "microsoftfabric_pipeline_user" "deployment_service_principal" {
+  pipeline_id    = microsoftfabric_pipeline.example_pipeline.id
+  principal_id   = "22222222-2222-2222-2222-222222222222"
+  principal_type = "ServicePrincipal"
+  role           = "Admin"
+}
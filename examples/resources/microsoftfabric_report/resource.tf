@@ -0,0 +1,30 @@
+# Lakehouse -> semantic model -> report in one root module
+resource "microsoftfabric_lakehouse" "example_lakehouse" {
+  workspace_id = microsoftfabric_workspace.example.id
+  display_name = "sales_lakehouse"
+}
+
+resource "microsoftfabric_semantic_model" "example_semantic_model" {
+  workspace_id          = microsoftfabric_workspace.example.id
+  display_name          = "sales_model"
+  tmdl_folder_path      = "${path.module}/Sales.SemanticModel/definition"
+  definition_pbism_path = "${path.module}/Sales.SemanticModel/definition.pbism"
+
+  depends_on = [microsoftfabric_lakehouse.example_lakehouse]
+}
+
+# Report from a PBIR folder, bound to the semantic model above
+resource "microsoftfabric_report" "example_report" {
+  workspace_id       = microsoftfabric_workspace.example.id
+  display_name       = "sales_report"
+  report_folder_path = "${path.module}/Sales.Report"
+  semantic_model_id  = microsoftfabric_semantic_model.example_semantic_model.id
+}
+
+# Report from a legacy report.json
+resource "microsoftfabric_report" "example_report_legacy" {
+  workspace_id      = microsoftfabric_workspace.example.id
+  display_name      = "sales_report_legacy"
+  report_json_path  = "${path.module}/SalesLegacy.Report/report.json"
+  semantic_model_id = microsoftfabric_semantic_model.example_semantic_model.id
+}
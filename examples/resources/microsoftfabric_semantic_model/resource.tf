@@ -0,0 +1,16 @@
+# Semantic model from a TMDL folder (the "definition" folder of a Power BI project)
+resource "microsoftfabric_semantic_model" "example_semantic_model" {
+  workspace_id          = microsoftfabric_workspace.example.id
+  display_name          = "sales_model"
+  description           = "Sales semantic model deployed from TMDL"
+  tmdl_folder_path      = "${path.module}/Sales.SemanticModel/definition"
+  definition_pbism_path = "${path.module}/Sales.SemanticModel/definition.pbism"
+}
+
+# Semantic model from a model.bim file
+resource "microsoftfabric_semantic_model" "example_semantic_model_bim" {
+  workspace_id          = microsoftfabric_workspace.example.id
+  display_name          = "finance_model"
+  model_bim_path        = "${path.module}/Finance.SemanticModel/model.bim"
+  definition_pbism_path = "${path.module}/Finance.SemanticModel/definition.pbism"
+}
@@ -11,4 +11,38 @@ resource "microsoftfabric_shortcut" "example_shortcut" {
       connection_id = "9c08dcab-4d97-448d-8f42-7b0ba25bdc7a"                 # Replace with your connection ID
     }
   }
-}
\ No newline at end of file
+}
+
+# Cross-workspace shortcut to a table of the bronze lakehouse.
+resource "microsoftfabric_shortcut" "example_onelake_shortcut" {
+  workspace_id = microsoftfabric_workspace.example.id
+  item_id      = microsoftfabric_lakehouse.example_lakehouse.id
+  path         = "Tables"
+  name         = "customers"
+
+  target = {
+    one_lake = {
+      workspace_id = "00000000-0000-0000-0000-000000000000" # Workspace of the source lakehouse
+      item_id      = "11111111-1111-1111-1111-111111111111" # Source lakehouse
+      path         = "Tables/customers"
+    }
+  }
+}
+
+# Shortcut whose name may already be taken; Fabric generates a unique name instead of failing.
+# Changes are applied by creating the new shortcut before removing the old one.
+resource "microsoftfabric_shortcut" "example_unique_shortcut" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  item_id         = microsoftfabric_lakehouse.example_lakehouse.id
+  path            = "Files/landing zone"
+  name            = "sales #2024"
+  conflict_policy = "GenerateUniqueName"
+
+  target = {
+    adls_gen2 = {
+      location      = "https://blobstoragedemoterragen.dfs.core.windows.net"
+      subpath       = "/sales"
+      connection_id = "9c08dcab-4d97-448d-8f42-7b0ba25bdc7a"
+    }
+  }
+}
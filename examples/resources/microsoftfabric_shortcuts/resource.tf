@@ -0,0 +1,31 @@
+# All shortcuts of the silver lakehouse, managed as one resource.
+resource "microsoftfabric_shortcuts" "silver_shortcuts" {
+  workspace_id    = microsoftfabric_workspace.example.id
+  item_id         = microsoftfabric_lakehouse.example_lakehouse.id
+  max_concurrency = 10
+
+  shortcuts = {
+    customers = {
+      path = "Tables"
+      name = "customers"
+      target = {
+        one_lake = {
+          workspace_id = "00000000-0000-0000-0000-000000000000"
+          item_id      = "11111111-1111-1111-1111-111111111111"
+          path         = "Tables/customers"
+        }
+      }
+    }
+    raw_sales = {
+      path = "Files/raw"
+      name = "sales"
+      target = {
+        adls_gen2 = {
+          location      = "https://blobstoragedemoterragen.dfs.core.windows.net"
+          subpath       = "/sales"
+          connection_id = "9c08dcab-4d97-448d-8f42-7b0ba25bdc7a"
+        }
+      }
+    }
+  }
+}
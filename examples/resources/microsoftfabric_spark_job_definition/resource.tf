@@ -0,0 +1,11 @@
+resource "microsoftfabric_spark_job_definition" "example_job" {
+  workspace_id           = microsoftfabric_workspace.example.id
+  display_name           = "spark_job_demo"
+  description            = "An example"
+  language               = "Python"
+  main_file_path         = "${path.module}/jobs/main.py"
+  reference_file_paths   = ["${path.module}/jobs/helpers.py"]
+  command_line_arguments = "--date 2024-01-01"
+  default_lakehouse_id   = microsoftfabric_lakehouse.example.id
+  environment_id         = microsoftfabric_environment.example.id
+}
@@ -0,0 +1,10 @@
+resource "microsoftfabric_warehouse" "example_warehouse" {
+  workspace_id               = microsoftfabric_workspace.example.id
+  display_name               = "warehouse_demo"
+  description                = "An example"
+  case_insensitive_collation = true
+}
+
+output "warehouse_connection_string" {
+  value = microsoftfabric_warehouse.example_warehouse.connection_string
+}
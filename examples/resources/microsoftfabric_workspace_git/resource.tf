@@ -11,4 +11,23 @@ resource "microsoftfabric_workspace_git" "example_git" {
   }
 
   initialization_strategy = "PreferRemote"
-}
\ No newline at end of file
+}
+
+resource "microsoftfabric_workspace_git" "example_github" {
+  workspace_id = microsoftfabric_workspace.example.id
+
+  git_provider_details = {
+    owner_name        = "contoso"
+    git_provider_type = "GitHub"
+    repository_name   = "fabric-items"
+    branch_name       = "main"
+    directory_name    = "/workspace"
+  }
+
+  my_git_credentials = {
+    source        = "ConfiguredConnection"
+    connection_id = "00000000-0000-0000-0000-000000000000"
+  }
+
+  initialization_strategy = "PreferRemote"
+}
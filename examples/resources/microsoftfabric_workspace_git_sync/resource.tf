@@ -0,0 +1,11 @@
+# Pull new commits of the connected branch into the workspace on every apply.
+resource "microsoftfabric_workspace_git_sync" "example_git_sync" {
+  workspace_id         = microsoftfabric_workspace_git.example_git.workspace_id
+  direction            = "UpdateFromGit"
+  conflict_resolution  = "PreferRemote"
+  allow_override_items = true
+}
+
+output "pending_git_changes" {
+  value = microsoftfabric_workspace_git_sync.example_git_sync.pending_changes
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 )
 
@@ -22,6 +25,18 @@ type APIClient struct {
 	Token         string
 	TokenExpiry   time.Time
 	TokenFilePath string
+
+	// tokenMutex serializes token renewal for resources that send requests concurrently.
+	tokenMutex sync.Mutex
+
+	scopedTokens      map[string]scopedToken
+	scopedTokensMutex sync.Mutex
+}
+
+// scopedToken is a cached access token for a scope other than the Fabric API.
+type scopedToken struct {
+	token  string
+	expiry time.Time
 }
 
 // NewAPIClient initializes a new APIClient.
@@ -84,19 +99,47 @@ func (c *APIClient) saveTokenToFile(tokenData map[string]interface{}) error {
 	return json.NewEncoder(file).Encode(tokenData)
 }
 
-// GetAccessToken retrieves an access token from Azure AD using username and password.
-func (c *APIClient) GetAccessToken() error {
+// GetAccessToken returns a valid access token, retrieving a new one from Azure AD when it expired.
+// The token is read and renewed under the lock, so callers must use the returned value instead of Token.
+func (c *APIClient) GetAccessToken() (string, error) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
 	// Check if the token is still valid
 	if c.Token != "" && time.Now().Before(c.TokenExpiry) {
-		return nil
+		return c.Token, nil
+	}
+
+	result, err := c.requestToken("https://analysis.windows.net/powerbi/api/.default")
+	if err != nil {
+		return "", err
 	}
 
+	if token, ok := result["access_token"].(string); ok {
+		c.Token = token
+		c.TokenExpiry = time.Now().Add(time.Duration(result["expires_in"].(float64)) * time.Second)
+
+		// Save the token to file if a token file path is provided.
+		if c.TokenFilePath != "" {
+			if err := c.saveTokenToFile(result); err != nil {
+				return "", fmt.Errorf("failed to save token to file: %v", err)
+			}
+		}
+
+		return token, nil
+	}
+
+	return "", fmt.Errorf("failed to get access token")
+}
+
+// requestToken requests a new access token for the given scope from Azure AD.
+func (c *APIClient) requestToken(scope string) (map[string]interface{}, error) {
 	authorityURL := "https://login.microsoftonline.com/" + c.TenantID + "/oauth2/v2.0/token"
 	form := url.Values{}
 	form.Set("client_id", c.ClientID)
 	form.Set("client_secret", c.ClientSecret)
 	form.Set("grant_type", "client_credentials")
-	form.Set("scope", "https://analysis.windows.net/powerbi/api/.default")
+	form.Set("scope", scope)
 
 	if c.Username != "" { //use ROPC Flow (username+password) for authentication and overwrite values
 		form.Set("grant_type", "password")
@@ -106,62 +149,69 @@ func (c *APIClient) GetAccessToken() error {
 
 	resp, err := http.PostForm(authorityURL, form)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get access token: %s", string(body))
+		return nil, fmt.Errorf("failed to get access token: %s", string(body))
 	}
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
+		return nil, err
 	}
 
-	if token, ok := result["access_token"].(string); ok {
-		c.Token = token
-		c.TokenExpiry = time.Now().Add(time.Duration(result["expires_in"].(float64)) * time.Second)
+	return result, nil
+}
 
-		// Save the token to file if a token file path is provided.
-		if c.TokenFilePath != "" {
-			if err := c.saveTokenToFile(result); err != nil {
-				return fmt.Errorf("failed to save token to file: %v", err)
-			}
-		}
+// GetAccessTokenForScope returns a token for another API than Fabric, e.g. Kusto or OneLake storage.
+// Tokens are cached per scope until they expire.
+func (c *APIClient) GetAccessTokenForScope(scope string) (string, error) {
+	c.scopedTokensMutex.Lock()
+	defer c.scopedTokensMutex.Unlock()
 
-		return nil
+	if cached, ok := c.scopedTokens[scope]; ok && time.Now().Before(cached.expiry) {
+		return cached.token, nil
 	}
 
-	return fmt.Errorf("failed to get access token")
-}
+	result, err := c.requestToken(scope)
+	if err != nil {
+		return "", err
+	}
 
-func (c *APIClient) Get(url string) (map[string]interface{}, error) {
-	// Ensure we have a valid token.
-	if err := c.GetAccessToken(); err != nil {
-		return nil, fmt.Errorf("failed to acquire token: %v", err)
+	token, ok := result["access_token"].(string)
+	if !ok {
+		return "", fmt.Errorf("failed to get access token for scope %s", scope)
 	}
+	expiresIn, _ := result["expires_in"].(float64)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+	if c.scopedTokens == nil {
+		c.scopedTokens = make(map[string]scopedToken)
+	}
+	// Renew a minute early so that tokens do not expire in the middle of a request.
+	c.scopedTokens[scope] = scopedToken{
+		token:  token,
+		expiry: time.Now().Add(time.Duration(expiresIn)*time.Second - time.Minute),
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return token, nil
+}
+
+// Get makes a GET request to the specified URL. Throttled requests are retried.
+func (c *APIClient) Get(url string) (map[string]interface{}, error) {
+	resp, responseBodyBytes, err := c.sendWithThrottlingRetry("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, fmt.Errorf("resource not found: %v", resp.Status)
 	}
 
 	var respBody map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
+	if err := json.Unmarshal(responseBodyBytes, &respBody); err != nil {
 		return nil, err
 	}
 
@@ -171,7 +221,8 @@ func (c *APIClient) Get(url string) (map[string]interface{}, error) {
 // Post makes a POST request to the specified URL with the given body.
 func (c *APIClient) Post(url string, body map[string]interface{}) (map[string]interface{}, error) {
 	// Ensure we have a valid token
-	if err := c.GetAccessToken(); err != nil {
+	token, err := c.GetAccessToken()
+	if err != nil {
 		return nil, fmt.Errorf("failed to acquire token: %v", err)
 	}
 
@@ -185,7 +236,7 @@ func (c *APIClient) Post(url string, body map[string]interface{}) (map[string]in
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -225,26 +276,12 @@ func (c *APIClient) Post(url string, body map[string]interface{}) (map[string]in
 	return responseBody, nil
 }
 
-// Delete makes a DELETE request to the specified URL.
+// Delete makes a DELETE request to the specified URL. Throttled requests are retried.
 func (c *APIClient) Delete(url string) error {
-	// Ensure we have a valid token
-	if err := c.GetAccessToken(); err != nil {
-		return fmt.Errorf("failed to acquire token: %v", err)
-	}
-
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, _, err := c.sendWithThrottlingRetry("DELETE", url, nil)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
 	// Check the HTTP status code.
 	if resp.StatusCode != http.StatusOK {
@@ -256,7 +293,8 @@ func (c *APIClient) Delete(url string) error {
 
 func (c *APIClient) Patch(url string, body map[string]interface{}) (map[string]interface{}, error) {
 	// Ensure we have a valid token.
-	if err := c.GetAccessToken(); err != nil {
+	token, err := c.GetAccessToken()
+	if err != nil {
 		return nil, fmt.Errorf("failed to acquire token: %v", err)
 	}
 
@@ -266,7 +304,7 @@ func (c *APIClient) Patch(url string, body map[string]interface{}) (map[string]i
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -306,7 +344,8 @@ func (c *APIClient) Patch(url string, body map[string]interface{}) (map[string]i
 // Put makes a PUT request to the specified URL with the given body.
 func (c *APIClient) Put(url string, body map[string]interface{}) (map[string]interface{}, error) {
 	// Ensure we have a valid token.
-	if err := c.GetAccessToken(); err != nil {
+	token, err := c.GetAccessToken()
+	if err != nil {
 		return nil, fmt.Errorf("failed to acquire token: %v", err)
 	}
 
@@ -316,7 +355,7 @@ func (c *APIClient) Put(url string, body map[string]interface{}) (map[string]int
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -356,7 +395,8 @@ func (c *APIClient) Put(url string, body map[string]interface{}) (map[string]int
 // PostBytes makes a POST request to the specified URL with the given body as bytes.
 func (c *APIClient) PostBytes(url string, bodyBytes []byte) (map[string]interface{}, error) {
 	// Ensure we have a valid token.
-	if err := c.GetAccessToken(); err != nil {
+	token, err := c.GetAccessToken()
+	if err != nil {
 		return nil, fmt.Errorf("failed to acquire token: %v", err)
 	}
 
@@ -365,7 +405,7 @@ func (c *APIClient) PostBytes(url string, bodyBytes []byte) (map[string]interfac
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -407,7 +447,8 @@ func (c *APIClient) PostBytes(url string, bodyBytes []byte) (map[string]interfac
 // PostWithOperationCheck makes a POST request and checks the status of the long-running operation.
 func (c *APIClient) PostWithOperationCheck(url string, body map[string]interface{}) (map[string]interface{}, error) {
 	// Ensure we have a valid token
-	if err := c.GetAccessToken(); err != nil {
+	token, err := c.GetAccessToken()
+	if err != nil {
 		return nil, fmt.Errorf("failed to acquire token: %v", err)
 	}
 
@@ -421,7 +462,7 @@ func (c *APIClient) PostWithOperationCheck(url string, body map[string]interface
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -455,11 +496,15 @@ func (c *APIClient) pollOperationResult(operationID string) (map[string]interfac
 	client := &http.Client{}
 	for {
 		url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/operations/%s/result", operationID)
+		token, err := c.GetAccessToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire token: %v", err)
+		}
 		req, err := http.NewRequest("GET", url, nil)
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 		resp, err := client.Do(req)
 		if err != nil {
@@ -492,7 +537,8 @@ func (c *APIClient) pollOperationResult(operationID string) (map[string]interfac
 // Patch makes a PATCH request to the specified URL with the given body.
 func (c *APIClient) PatchBytes(url string, body map[string]interface{}) (map[string]interface{}, error) {
 	// Ensure we have a valid token.
-	if err := c.GetAccessToken(); err != nil {
+	token, err := c.GetAccessToken()
+	if err != nil {
 		return nil, fmt.Errorf("failed to acquire token: %v", err)
 	}
 
@@ -506,7 +552,7 @@ func (c *APIClient) PatchBytes(url string, body map[string]interface{}) (map[str
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -544,3 +590,279 @@ func (c *APIClient) PatchBytes(url string, body map[string]interface{}) (map[str
 
 	return responseBody, nil
 }
+
+// FabricError describes a failed Fabric REST API call, including the service error code when one is returned.
+type FabricError struct {
+	StatusCode int
+	ErrorCode  string
+	Message    string
+	Body       string
+}
+
+func (e *FabricError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("request failed with status code %d (%s): %s", e.StatusCode, e.ErrorCode, e.Message)
+	}
+	return fmt.Sprintf("request failed with status code %d: %s", e.StatusCode, e.Body)
+}
+
+// newFabricError builds a FabricError from a non-success response body.
+func newFabricError(statusCode int, bodyBytes []byte) *FabricError {
+	fabricErr := &FabricError{StatusCode: statusCode, Body: string(bodyBytes)}
+
+	var errorBody struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	}
+	if err := json.Unmarshal(bodyBytes, &errorBody); err == nil {
+		fabricErr.ErrorCode = errorBody.ErrorCode
+		fabricErr.Message = errorBody.Message
+	}
+
+	return fabricErr
+}
+
+// PostWithLongRunningOperation makes a POST request and, when the service answers with 202 Accepted,
+// polls the long-running operation until it finishes. The operation result is returned if there is one.
+func (c *APIClient) PostWithLongRunningOperation(url string, body map[string]interface{}) (map[string]interface{}, error) {
+	return c.doWithLongRunningOperation("POST", url, body)
+}
+
+// GetWithLongRunningOperation makes a GET request for APIs that may compute their answer as a
+// long-running operation, such as the Git status of a workspace.
+func (c *APIClient) GetWithLongRunningOperation(url string) (map[string]interface{}, error) {
+	return c.doWithLongRunningOperation("GET", url, nil)
+}
+
+// StartLongRunningOperation makes a POST request for an API that answers with 202 Accepted and returns the
+// ID of the long-running operation and the requested polling interval without waiting for the operation.
+// Callers that need to keep the operation ID, e.g. to store it in the state, wait with WaitForOperation.
+func (c *APIClient) StartLongRunningOperation(url string, body map[string]interface{}) (string, time.Duration, error) {
+	resp, responseBodyBytes, err := c.sendWithThrottlingRetry("POST", url, body)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return "", 0, newFabricError(resp.StatusCode, responseBodyBytes)
+	}
+	operationID := resp.Header.Get("x-ms-operation-id")
+	if operationID == "" {
+		return "", 0, fmt.Errorf("no operation ID found in response")
+	}
+
+	return operationID, retryAfter(resp), nil
+}
+
+// doWithLongRunningOperation sends the request and follows a 202 Accepted answer until the operation finishes.
+func (c *APIClient) doWithLongRunningOperation(method, url string, body map[string]interface{}) (map[string]interface{}, error) {
+	resp, responseBodyBytes, err := c.sendWithThrottlingRetry(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return parseResponseBody(responseBodyBytes)
+
+	case http.StatusAccepted:
+		operationID := resp.Header.Get("x-ms-operation-id")
+		if operationID == "" {
+			return nil, fmt.Errorf("no operation ID found in response")
+		}
+		return c.WaitForOperation(operationID, retryAfter(resp))
+
+	default:
+		return nil, newFabricError(resp.StatusCode, responseBodyBytes)
+	}
+}
+
+// sendWithThrottlingRetry sends a JSON request and returns the response with its body already read.
+// Throttled (429) requests are retried after the interval the service asks for.
+func (c *APIClient) sendWithThrottlingRetry(method, url string, body map[string]interface{}) (*http.Response, []byte, error) {
+	var bodyBytes []byte
+	if body != nil {
+		var err error
+		bodyBytes, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request body: %v", err)
+		}
+	}
+
+	client := &http.Client{}
+	for attempt := 0; ; attempt++ {
+		// Ensure we have a valid token; a throttled request may wait until it expires.
+		token, err := c.GetAccessToken()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to acquire token: %v", err)
+		}
+
+		var requestBody io.Reader
+		if bodyBytes != nil {
+			requestBody = bytes.NewBuffer(bodyBytes)
+		}
+
+		req, err := http.NewRequest(method, url, requestBody)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		responseBodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %v", err)
+		}
+
+		// Fabric throttles bursts of requests; wait as long as the service asks before retrying.
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxThrottledRetries {
+			return resp, responseBodyBytes, nil
+		}
+		time.Sleep(retryAfter(resp))
+	}
+}
+
+// WaitForOperation polls a Fabric long-running operation until it reaches a terminal state.
+// On success the operation result is returned, or an empty map if the operation has no result.
+func (c *APIClient) WaitForOperation(operationID string, interval time.Duration) (map[string]interface{}, error) {
+	client := &http.Client{}
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/operations/%s", operationID)
+
+	for {
+		time.Sleep(interval)
+
+		// Long operations can outlive the token, so refresh it on every poll.
+		token, err := c.GetAccessToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire token: %v", err)
+		}
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		responseBodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, newFabricError(resp.StatusCode, responseBodyBytes)
+		}
+
+		var operation struct {
+			Status string `json:"status"`
+			Error  struct {
+				ErrorCode string `json:"errorCode"`
+				Message   string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(responseBodyBytes, &operation); err != nil {
+			return nil, fmt.Errorf("failed to parse operation state: %v", err)
+		}
+
+		switch operation.Status {
+		case "Succeeded":
+			// The Location header only points to a result when the operation produced one.
+			if location := resp.Header.Get("Location"); location != "" {
+				return c.Get(location)
+			}
+			return map[string]interface{}{}, nil
+
+		case "Failed", "Undetermined":
+			return nil, &FabricError{
+				StatusCode: resp.StatusCode,
+				ErrorCode:  operation.Error.ErrorCode,
+				Message:    fmt.Sprintf("operation %s %s: %s", operationID, operation.Status, operation.Error.Message),
+				Body:       string(responseBodyBytes),
+			}
+		}
+
+		interval = retryAfter(resp)
+	}
+}
+
+// maxThrottledRetries is how often a throttled (429) request is retried before giving up.
+const maxThrottledRetries = 5
+
+// retryAfter returns the polling interval requested by the service, defaulting to two seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 2 * time.Second
+}
+
+// parseResponseBody decodes a JSON response body, returning an empty map for empty bodies.
+func parseResponseBody(bodyBytes []byte) (map[string]interface{}, error) {
+	if len(bodyBytes) == 0 {
+		return make(map[string]interface{}), nil
+	}
+
+	var responseBody map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &responseBody); err != nil {
+		return nil, fmt.Errorf("failed to parse response body: %v", err)
+	}
+
+	return responseBody, nil
+}
+
+// PostMultipartFile uploads a single file as multipart/form-data to the specified URL.
+func (c *APIClient) PostMultipartFile(url, fieldName, fileName string, content []byte) (map[string]interface{}, error) {
+	// Ensure we have a valid token.
+	token, err := c.GetAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire token: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	part, err := writer.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart body: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, fmt.Errorf("failed to write multipart body: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart body: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	// Handle non-success status codes.
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, newFabricError(resp.StatusCode, responseBodyBytes)
+	}
+
+	return parseResponseBody(responseBodyBytes)
+}
@@ -0,0 +1,61 @@
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// dataAccessRolesURL returns the URL of the OneLake data access roles of an item.
+func dataAccessRolesURL(workspaceID, itemID string) string {
+	return fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/dataAccessRoles", workspaceID, itemID)
+}
+
+// ListDataAccessRoles returns all OneLake data access roles of an item, following continuation pages.
+func (c *APIClient) ListDataAccessRoles(workspaceID, itemID string) ([]map[string]interface{}, error) {
+	roles := []map[string]interface{}{}
+	requestURL := dataAccessRolesURL(workspaceID, itemID)
+
+	for requestURL != "" {
+		resp, responseBodyBytes, err := c.sendWithThrottlingRetry("GET", requestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, newFabricError(resp.StatusCode, responseBodyBytes)
+		}
+
+		page, err := parseResponseBody(responseBodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		values, _ := page["value"].([]interface{})
+		for _, value := range values {
+			if role, ok := value.(map[string]interface{}); ok {
+				roles = append(roles, role)
+			}
+		}
+
+		requestURL, _ = page["continuationUri"].(string)
+	}
+
+	return roles, nil
+}
+
+// ReplaceDataAccessRoles replaces the whole set of OneLake data access roles of an item in one request.
+// Roles that are not part of roles are deleted, so an empty slice removes every role.
+func (c *APIClient) ReplaceDataAccessRoles(workspaceID, itemID string, roles []map[string]interface{}) error {
+	if roles == nil {
+		roles = []map[string]interface{}{}
+	}
+
+	requestURL := dataAccessRolesURL(workspaceID, itemID) + "?dryRun=false"
+	resp, responseBodyBytes, err := c.sendWithThrottlingRetry("PUT", requestURL, map[string]interface{}{"value": roles})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return newFabricError(resp.StatusCode, responseBodyBytes)
+	}
+
+	return nil
+}
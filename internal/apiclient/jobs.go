@@ -0,0 +1,80 @@
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultJobPollInterval is how often a job instance is polled when the service does not ask for an interval.
+// Jobs such as table maintenance run for minutes, so polling is less frequent than for operations.
+const defaultJobPollInterval = 10 * time.Second
+
+// RunOnDemandJob starts a job of an item, e.g. TableMaintenance of a lakehouse, and returns the URL of the
+// new job instance. Use WaitForJobInstance to wait until the job finishes.
+func (c *APIClient) RunOnDemandJob(workspaceID, itemID, jobType string, executionData map[string]interface{}) (string, error) {
+	requestURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/jobs/instances?jobType=%s",
+		workspaceID, itemID, url.QueryEscape(jobType))
+
+	var body map[string]interface{}
+	if executionData != nil {
+		body = map[string]interface{}{"executionData": executionData}
+	}
+
+	resp, responseBodyBytes, err := c.sendWithThrottlingRetry("POST", requestURL, body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return "", newFabricError(resp.StatusCode, responseBodyBytes)
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("no job instance location found in response")
+	}
+	return location, nil
+}
+
+// WaitForJobInstance polls a job instance until it completes and returns the final job instance.
+// A failed, cancelled or deduplicated job instance is returned as a FabricError with the failure reason.
+func (c *APIClient) WaitForJobInstance(instanceURL string) (map[string]interface{}, error) {
+	for {
+		time.Sleep(defaultJobPollInterval)
+
+		// Long jobs can outlive the token, so refresh it on every poll.
+		resp, responseBodyBytes, err := c.sendWithThrottlingRetry("GET", instanceURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, newFabricError(resp.StatusCode, responseBodyBytes)
+		}
+
+		instance, err := parseResponseBody(responseBodyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		status, _ := instance["status"].(string)
+		switch status {
+		case "Completed":
+			return instance, nil
+
+		case "Failed", "Cancelled", "Deduped":
+			fabricErr := &FabricError{
+				StatusCode: resp.StatusCode,
+				Message:    fmt.Sprintf("job instance %s", status),
+				Body:       string(responseBodyBytes),
+			}
+			if reason, ok := instance["failureReason"].(map[string]interface{}); ok {
+				fabricErr.ErrorCode, _ = reason["errorCode"].(string)
+				if message, ok := reason["message"].(string); ok && message != "" {
+					fabricErr.Message = fmt.Sprintf("job instance %s: %s", status, message)
+				}
+			}
+			return instance, fabricErr
+		}
+	}
+}
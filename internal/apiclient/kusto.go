@@ -0,0 +1,91 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// KustoResultRow is a single row of a Kusto result table, keyed by column name.
+type KustoResultRow map[string]interface{}
+
+// kustoScope returns the token scope of a Kusto endpoint such as the query service URI of a KQL database.
+func kustoScope(serviceURI string) string {
+	return strings.TrimSuffix(serviceURI, "/") + "/.default"
+}
+
+// ExecuteKustoManagementCommand runs a management command (e.g. ".show tables") against a database
+// and returns the rows of the primary result table.
+func (c *APIClient) ExecuteKustoManagementCommand(serviceURI, database, command string) ([]KustoResultRow, error) {
+	token, err := c.GetAccessTokenForScope(kustoScope(serviceURI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire Kusto token: %v", err)
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"db":  database,
+		"csl": command,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %v", err)
+	}
+
+	requestURL := strings.TrimSuffix(serviceURI, "/") + "/v1/rest/mgmt"
+	req, err := http.NewRequest("POST", requestURL, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("management command failed with status code %d: %s", resp.StatusCode, string(responseBodyBytes))
+	}
+
+	// The v1 response contains a list of tables; the first one holds the command result.
+	var result struct {
+		Tables []struct {
+			Columns []struct {
+				ColumnName string `json:"ColumnName"`
+			} `json:"Columns"`
+			Rows [][]interface{} `json:"Rows"`
+		} `json:"Tables"`
+	}
+	if err := json.Unmarshal(responseBodyBytes, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response body: %v", err)
+	}
+
+	if len(result.Tables) == 0 {
+		return nil, nil
+	}
+
+	table := result.Tables[0]
+	rows := make([]KustoResultRow, 0, len(table.Rows))
+	for _, values := range table.Rows {
+		row := make(KustoResultRow, len(table.Columns))
+		for i, column := range table.Columns {
+			if i < len(values) {
+				row[column.ColumnName] = values[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,229 @@
+package apiclient
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OneLakeDFSEndpoint is the ADLS Gen2 compatible endpoint of OneLake.
+const OneLakeDFSEndpoint = "https://onelake.dfs.fabric.microsoft.com"
+
+// oneLakeScope is the token scope accepted by the OneLake DFS endpoint.
+const oneLakeScope = "https://storage.azure.com/.default"
+
+// oneLakeUploadChunkSize is the size of the blocks appended to a file, so that large files are not sent in one request.
+const oneLakeUploadChunkSize = 4 * 1024 * 1024
+
+// OneLakeURL builds the DFS URL of a path such as "<workspace id>/<item id>/Files/data.csv".
+// Every path segment is escaped, so names with spaces or '#' are safe.
+func OneLakeURL(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return OneLakeDFSEndpoint + "/" + strings.Join(segments, "/")
+}
+
+// OneLakeABFSSURL returns the abfss:// URI Spark uses to address a path inside an item.
+func OneLakeABFSSURL(workspaceID, itemID, path string) string {
+	return fmt.Sprintf("abfss://%s@onelake.dfs.fabric.microsoft.com/%s/%s", workspaceID, itemID, strings.TrimPrefix(path, "/"))
+}
+
+// doOneLakeRequest sends a request to the OneLake DFS endpoint with a storage-scoped token.
+func (c *APIClient) doOneLakeRequest(method, requestURL string, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	token, err := c.GetAccessTokenForScope(oneLakeScope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire storage token: %v", err)
+	}
+
+	req, err := http.NewRequest(method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("x-ms-version", "2023-11-03")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return resp, responseBodyBytes, fmt.Errorf("%s %s failed with status code %d: %s", method, requestURL, resp.StatusCode, string(responseBodyBytes))
+	}
+
+	return resp, responseBodyBytes, nil
+}
+
+// UploadOneLakeFile creates or overwrites a file in OneLake with the given content. The content is appended
+// in chunks and the MD5 of the whole file is stored as its Content-MD5, so that changes can be detected later.
+func (c *APIClient) UploadOneLakeFile(path string, content []byte) error {
+	fileURL := OneLakeURL(path)
+
+	if _, _, err := c.doOneLakeRequest("PUT", fileURL+"?resource=file", nil, nil); err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+
+	for position := 0; position < len(content); position += oneLakeUploadChunkSize {
+		end := position + oneLakeUploadChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+		if _, _, err := c.doOneLakeRequest("PATCH", fmt.Sprintf("%s?action=append&position=%d", fileURL, position), content[position:end], nil); err != nil {
+			return fmt.Errorf("failed to append data at position %d: %v", position, err)
+		}
+	}
+
+	checksum := md5.Sum(content)
+	headers := map[string]string{"x-ms-content-md5": base64.StdEncoding.EncodeToString(checksum[:])}
+	if _, _, err := c.doOneLakeRequest("PATCH", fmt.Sprintf("%s?action=flush&position=%d", fileURL, len(content)), nil, headers); err != nil {
+		return fmt.Errorf("failed to flush file: %v", err)
+	}
+
+	return nil
+}
+
+// CreateOneLakeDirectory creates a directory and any missing parent directories in OneLake. No ACLs are set,
+// OneLake manages access on the workspace and item level.
+func (c *APIClient) CreateOneLakeDirectory(path string) error {
+	if _, _, err := c.doOneLakeRequest("PUT", OneLakeURL(path)+"?resource=directory", nil, nil); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+	return nil
+}
+
+// OneLakePathProperties are the properties of a file or directory returned by GetOneLakePathProperties.
+type OneLakePathProperties struct {
+	IsDirectory   bool
+	ContentLength int64
+	// ContentMD5 is the base64 encoded MD5 stored with the file, empty if the file was written without one.
+	ContentMD5   string
+	LastModified string
+	ETag         string
+}
+
+// GetOneLakePathProperties returns the properties of a file or directory, or nil if the path does not exist.
+func (c *APIClient) GetOneLakePathProperties(path string) (*OneLakePathProperties, error) {
+	resp, _, err := c.doOneLakeRequest("HEAD", OneLakeURL(path), nil, nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	properties := &OneLakePathProperties{
+		IsDirectory:  resp.Header.Get("x-ms-resource-type") == "directory",
+		ContentMD5:   resp.Header.Get("Content-MD5"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ETag:         resp.Header.Get("ETag"),
+	}
+	fmt.Sscanf(resp.Header.Get("Content-Length"), "%d", &properties.ContentLength)
+
+	return properties, nil
+}
+
+// DeleteOneLakePath deletes a file or directory in OneLake. A path that does not exist is not an error.
+func (c *APIClient) DeleteOneLakePath(path string, recursive bool) error {
+	requestURL := OneLakeURL(path)
+	if recursive {
+		requestURL += "?recursive=true"
+	}
+
+	resp, _, err := c.doOneLakeRequest("DELETE", requestURL, nil, nil)
+	if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// OneLakePath describes a file or directory returned by ListOneLakePaths.
+type OneLakePath struct {
+	// Name is the path relative to the workspace, e.g. "<item id>/Files/data.csv".
+	Name          string
+	IsDirectory   bool
+	ContentLength int64
+	LastModified  string
+	ETag          string
+}
+
+// ListOneLakePaths lists the files and directories below a directory such as "<workspace id>/<item id>/Files/raw".
+func (c *APIClient) ListOneLakePaths(directory string, recursive bool) ([]OneLakePath, error) {
+	segments := strings.SplitN(strings.Trim(directory, "/"), "/", 2)
+	if len(segments) != 2 {
+		return nil, fmt.Errorf("directory %q must start with a workspace and an item", directory)
+	}
+
+	var paths []OneLakePath
+	continuation := ""
+	for {
+		query := url.Values{}
+		query.Set("resource", "filesystem")
+		query.Set("directory", segments[1])
+		query.Set("recursive", fmt.Sprintf("%t", recursive))
+		if continuation != "" {
+			query.Set("continuation", continuation)
+		}
+
+		resp, body, err := c.doOneLakeRequest("GET", OneLakeDFSEndpoint+"/"+url.PathEscape(segments[0])+"?"+query.Encode(), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var listing struct {
+			Paths []struct {
+				Name          string `json:"name"`
+				IsDirectory   string `json:"isDirectory"`
+				ContentLength string `json:"contentLength"`
+				LastModified  string `json:"lastModified"`
+				ETag          string `json:"etag"`
+			} `json:"paths"`
+		}
+		if err := json.Unmarshal(body, &listing); err != nil {
+			return nil, fmt.Errorf("failed to parse path listing: %v", err)
+		}
+
+		for _, path := range listing.Paths {
+			var contentLength int64
+			fmt.Sscanf(path.ContentLength, "%d", &contentLength)
+			paths = append(paths, OneLakePath{
+				Name:          path.Name,
+				IsDirectory:   path.IsDirectory == "true",
+				ContentLength: contentLength,
+				LastModified:  path.LastModified,
+				ETag:          path.ETag,
+			})
+		}
+
+		continuation = resp.Header.Get("x-ms-continuation")
+		if continuation == "" {
+			return paths, nil
+		}
+	}
+}
+
+// ReadOneLakeFile returns the content of a file in OneLake.
+func (c *APIClient) ReadOneLakeFile(path string) ([]byte, error) {
+	_, body, err := c.doOneLakeRequest("GET", OneLakeURL(path), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,865 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithModifyPlan = &environmentResource{}
+)
+
+// environmentPublishPollInterval is the time between two checks of the publish state.
+const environmentPublishPollInterval = 10 * time.Second
+
+// environmentPublishStartTimeout is how long a new publish may take to show up in the environment details.
+const environmentPublishStartTimeout = 2 * time.Minute
+
+// environmentDefaultNodeCores and environmentDefaultNodeMemory are the medium node size new environments use.
+const (
+	environmentDefaultNodeCores  = 8
+	environmentDefaultNodeMemory = "56g"
+)
+
+// Define the Spark environment resource.
+type environmentResource struct {
+	client *apiclient.APIClient
+}
+
+// Define the schema for the Spark environment resource.
+func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the environment.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the workspace where the environment will be created.",
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The display name of the environment.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional description of the environment.",
+			},
+			"spark_compute": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Staging Spark compute settings of the environment. They are published on every apply that changes them. Removing them resets the environment to the default pool and runtime of the workspace.",
+				Attributes: map[string]schema.Attribute{
+					"instance_pool_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Name of the Spark pool used by the environment, e.g. the name of a microsoftfabric_spark_pool or 'Starter Pool'.",
+					},
+					"instance_pool_type": schema.StringAttribute{
+						Required:    true,
+						Description: "Type of the Spark pool. Available options include 'Workspace' and 'Capacity'.",
+					},
+					"driver_cores": schema.Int64Attribute{
+						Required:    true,
+						Description: "Number of cores of the Spark driver, e.g. 4, 8, 16, 32 or 64.",
+					},
+					"driver_memory": schema.StringAttribute{
+						Required:    true,
+						Description: "Memory of the Spark driver, e.g. '28g' or '56g'.",
+					},
+					"executor_cores": schema.Int64Attribute{
+						Required:    true,
+						Description: "Number of cores of each Spark executor, e.g. 4, 8, 16, 32 or 64.",
+					},
+					"executor_memory": schema.StringAttribute{
+						Required:    true,
+						Description: "Memory of each Spark executor, e.g. '28g' or '56g'.",
+					},
+					"runtime_version": schema.StringAttribute{
+						Required:    true,
+						Description: "Fabric runtime version, e.g. '1.2' or '1.3'.",
+					},
+					"dynamic_executor_allocation": schema.SingleNestedAttribute{
+						Optional:    true,
+						Description: "Dynamic executor allocation properties.",
+						Attributes: map[string]schema.Attribute{
+							"enabled": schema.BoolAttribute{
+								Required:    true,
+								Description: "The status of the dynamic executor allocation. False - Disabled, true - Enabled.",
+							},
+							"min_executors": schema.Int64Attribute{
+								Required:    true,
+								Description: "The minimum number of executors.",
+							},
+							"max_executors": schema.Int64Attribute{
+								Required:    true,
+								Description: "The maximum number of executors.",
+							},
+						},
+					},
+					"spark_properties": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Spark properties set in the environment, e.g. spark.sql.shuffle.partitions.",
+					},
+				},
+			},
+			"environment_yml_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to an environment.yml file with the public libraries of the environment.",
+			},
+			"custom_library_paths": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Local paths to custom libraries (.whl, .py, .jar or .tar.gz files) uploaded to the environment.",
+			},
+			"libraries_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the local library files. A change of the files triggers a new upload and publish.",
+			},
+			"publish_state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the last publish operation of the environment, e.g. 'Success' or 'Failed'.",
+			},
+			"publish_target_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The environment version targeted by the last publish operation.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update.",
+			},
+		},
+	}
+}
+
+// Define the model for the Spark environment resource.
+type environmentResourceModel struct {
+	ID                   types.String                  `tfsdk:"id"`
+	WorkspaceID          types.String                  `tfsdk:"workspace_id"`
+	DisplayName          types.String                  `tfsdk:"display_name"`
+	Description          types.String                  `tfsdk:"description"`
+	SparkCompute         *environmentSparkComputeModel `tfsdk:"spark_compute"`
+	EnvironmentYmlPath   types.String                  `tfsdk:"environment_yml_path"`
+	CustomLibraryPaths   []types.String                `tfsdk:"custom_library_paths"`
+	LibrariesHash        types.String                  `tfsdk:"libraries_hash"`
+	PublishState         types.String                  `tfsdk:"publish_state"`
+	PublishTargetVersion types.String                  `tfsdk:"publish_target_version"`
+	LastUpdated          types.String                  `tfsdk:"last_updated"`
+}
+
+type environmentSparkComputeModel struct {
+	InstancePoolName          types.String                    `tfsdk:"instance_pool_name"`
+	InstancePoolType          types.String                    `tfsdk:"instance_pool_type"`
+	DriverCores               types.Int64                     `tfsdk:"driver_cores"`
+	DriverMemory              types.String                    `tfsdk:"driver_memory"`
+	ExecutorCores             types.Int64                     `tfsdk:"executor_cores"`
+	ExecutorMemory            types.String                    `tfsdk:"executor_memory"`
+	RuntimeVersion            types.String                    `tfsdk:"runtime_version"`
+	DynamicExecutorAllocation *DynamicExecutorAllocationModel `tfsdk:"dynamic_executor_allocation"`
+	SparkProperties           types.Map                       `tfsdk:"spark_properties"`
+}
+
+// Implement Metadata method.
+func (r *environmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_environment"
+}
+
+// Define the provider.
+func NewEnvironmentResource(client *apiclient.APIClient) resource.Resource {
+	return &environmentResource{client: client}
+}
+
+// ModifyPlan hashes the local library files so that content changes show up in the plan.
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan environmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.EnvironmentYmlPath.IsUnknown() {
+		return
+	}
+	for _, libraryPath := range plan.CustomLibraryPaths {
+		if libraryPath.IsUnknown() {
+			return
+		}
+	}
+
+	libraries, err := environmentLibraryFiles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment libraries",
+			"Could not read local environment libraries: "+err.Error(),
+		)
+		return
+	}
+	plan.LibrariesHash = types.StringValue(hashDefinitionParts(libraries))
+
+	// Changed library files are published again without a config change, so the framework left these known.
+	if !req.State.Raw.IsNull() {
+		var state environmentResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.LibrariesHash.Equal(state.LibrariesHash) {
+			plan.PublishState = types.StringUnknown()
+			plan.PublishTargetVersion = types.StringUnknown()
+			plan.LastUpdated = types.StringUnknown()
+		}
+	}
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement CRUD operations.
+func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan environmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	libraries, err := environmentLibraryFiles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment libraries",
+			"Could not read local environment libraries: "+err.Error(),
+		)
+		return
+	}
+
+	environmentID, err := r.createEnvironment(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating environment",
+			"Could not create environment: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(environmentID)
+	plan.LibrariesHash = types.StringValue(hashDefinitionParts(libraries))
+	plan.PublishState = types.StringNull()
+	plan.PublishTargetVersion = types.StringNull()
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Save the environment right away so that a failed publish does not orphan it.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	staged := false
+	if plan.SparkCompute != nil {
+		diags = r.updateStagingSparkCompute(ctx, plan.WorkspaceID.ValueString(), environmentID, plan.SparkCompute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		staged = true
+	}
+
+	for _, library := range libraries {
+		err = r.uploadStagingLibrary(plan.WorkspaceID.ValueString(), environmentID, library)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error uploading environment library",
+				fmt.Sprintf("Could not upload library %s: %s", library.Path, err.Error()),
+			)
+			return
+		}
+		staged = true
+	}
+
+	if staged {
+		diags = r.publishEnvironment(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state environmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := r.readEnvironment(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading environment",
+			"Could not read environment: "+err.Error(),
+		)
+		return
+	}
+
+	displayName, ok := environment["displayName"].(string)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error reading environment",
+			"Unexpected response format: 'displayName' key not found or not a string",
+		)
+		return
+	}
+	description, _ := environment["description"].(string) // description is optional
+
+	state.DisplayName = types.StringValue(displayName)
+	if !state.Description.IsNull() || description != "" {
+		state.Description = types.StringValue(description)
+	}
+	setEnvironmentPublishDetails(&state, environment)
+
+	// Refresh the published Spark compute so that changes made in the portal show up as drift.
+	if state.SparkCompute != nil {
+		sparkCompute, err := r.client.Get(fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s/sparkcompute", state.WorkspaceID.ValueString(), state.ID.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading environment Spark compute",
+				"Could not read published Spark compute: "+err.Error(),
+			)
+			return
+		}
+
+		diags = setEnvironmentSparkCompute(ctx, state.SparkCompute, sparkCompute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan environmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state environmentResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceID := state.WorkspaceID.ValueString()
+	environmentID := state.ID.ValueString()
+	plan.ID = state.ID // Preserve the existing ID
+
+	if !plan.DisplayName.Equal(state.DisplayName) || !plan.Description.Equal(state.Description) {
+		err := r.updateEnvironment(workspaceID, environmentID, plan.DisplayName.ValueString(), plan.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating environment",
+				"Could not update environment: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	staged := false
+	switch {
+	case plan.SparkCompute != nil && !environmentSparkComputeEqual(plan.SparkCompute, state.SparkCompute):
+		diags = r.updateStagingSparkCompute(ctx, workspaceID, environmentID, plan.SparkCompute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		staged = true
+
+	case plan.SparkCompute == nil && state.SparkCompute != nil:
+		// Removing spark_compute hands the environment back to the defaults of the workspace.
+		defaults, err := r.defaultSparkCompute(workspaceID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error resetting environment Spark compute",
+				"Could not read the default Spark settings of the workspace: "+err.Error(),
+			)
+			return
+		}
+		diags = r.updateStagingSparkCompute(ctx, workspaceID, environmentID, defaults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		staged = true
+	}
+
+	libraries, err := environmentLibraryFiles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment libraries",
+			"Could not read local environment libraries: "+err.Error(),
+		)
+		return
+	}
+	plan.LibrariesHash = types.StringValue(hashDefinitionParts(libraries))
+
+	if !plan.LibrariesHash.Equal(state.LibrariesHash) {
+		// Remove libraries that are no longer configured, then upload the current set.
+		planned := make(map[string]struct{}, len(libraries))
+		for _, library := range libraries {
+			planned[library.Path] = struct{}{}
+		}
+
+		for _, name := range environmentLibraryFileNames(state) {
+			if _, ok := planned[name]; ok {
+				continue
+			}
+			err = r.deleteStagingLibrary(workspaceID, environmentID, name)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error removing environment library",
+					fmt.Sprintf("Could not remove library %s: %s", name, err.Error()),
+				)
+				return
+			}
+		}
+
+		for _, library := range libraries {
+			err = r.uploadStagingLibrary(workspaceID, environmentID, library)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error uploading environment library",
+					fmt.Sprintf("Could not upload library %s: %s", library.Path, err.Error()),
+				)
+				return
+			}
+		}
+		staged = true
+	}
+
+	plan.PublishState = state.PublishState
+	plan.PublishTargetVersion = state.PublishTargetVersion
+	if staged {
+		diags = r.publishEnvironment(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state environmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.deleteEnvironment(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting environment",
+			"Could not delete environment: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// environmentLibraryFiles reads environment.yml and the custom libraries. The part path is the uploaded file name.
+func environmentLibraryFiles(model environmentResourceModel) ([]definitionPart, error) {
+	var libraries []definitionPart
+
+	if !model.EnvironmentYmlPath.IsNull() {
+		library, err := readDefinitionFile(model.EnvironmentYmlPath.ValueString(), "environment.yml")
+		if err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, library)
+	}
+
+	seen := make(map[string]struct{})
+	for _, libraryPath := range model.CustomLibraryPaths {
+		name := filepath.Base(libraryPath.ValueString())
+		if _, exists := seen[name]; exists {
+			return nil, fmt.Errorf("duplicate custom library file name: %s", name)
+		}
+		seen[name] = struct{}{}
+
+		library, err := readDefinitionFile(libraryPath.ValueString(), name)
+		if err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, library)
+	}
+
+	return libraries, nil
+}
+
+// environmentLibraryFileNames returns the names under which the libraries of a model were uploaded.
+func environmentLibraryFileNames(model environmentResourceModel) []string {
+	var names []string
+	if !model.EnvironmentYmlPath.IsNull() {
+		names = append(names, "environment.yml")
+	}
+	for _, libraryPath := range model.CustomLibraryPaths {
+		names = append(names, filepath.Base(libraryPath.ValueString()))
+	}
+
+	return names
+}
+
+// environmentSparkComputeEqual reports whether two Spark compute configurations are the same.
+func environmentSparkComputeEqual(a, b *environmentSparkComputeModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if (a.DynamicExecutorAllocation == nil) != (b.DynamicExecutorAllocation == nil) {
+		return false
+	}
+	if a.DynamicExecutorAllocation != nil && (!a.DynamicExecutorAllocation.Enabled.Equal(b.DynamicExecutorAllocation.Enabled) ||
+		!a.DynamicExecutorAllocation.MinExecutors.Equal(b.DynamicExecutorAllocation.MinExecutors) ||
+		!a.DynamicExecutorAllocation.MaxExecutors.Equal(b.DynamicExecutorAllocation.MaxExecutors)) {
+		return false
+	}
+
+	return a.InstancePoolName.Equal(b.InstancePoolName) &&
+		a.InstancePoolType.Equal(b.InstancePoolType) &&
+		a.DriverCores.Equal(b.DriverCores) &&
+		a.DriverMemory.Equal(b.DriverMemory) &&
+		a.ExecutorCores.Equal(b.ExecutorCores) &&
+		a.ExecutorMemory.Equal(b.ExecutorMemory) &&
+		a.RuntimeVersion.Equal(b.RuntimeVersion) &&
+		a.SparkProperties.Equal(b.SparkProperties)
+}
+
+// setEnvironmentPublishDetails copies the publish details from an environment response into the model.
+func setEnvironmentPublishDetails(model *environmentResourceModel, environment map[string]interface{}) {
+	properties, _ := environment["properties"].(map[string]interface{})
+	publishDetails, _ := properties["publishDetails"].(map[string]interface{})
+
+	if state, ok := getMapString("state", publishDetails); ok {
+		model.PublishState = types.StringValue(state)
+	} else {
+		model.PublishState = types.StringNull()
+	}
+	if targetVersion, ok := getMapString("targetVersion", publishDetails); ok {
+		model.PublishTargetVersion = types.StringValue(targetVersion)
+	} else {
+		model.PublishTargetVersion = types.StringNull()
+	}
+}
+
+// setEnvironmentSparkCompute copies a Spark compute response into the model.
+func setEnvironmentSparkCompute(ctx context.Context, model *environmentSparkComputeModel, sparkCompute map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if instancePool, ok := sparkCompute["instancePool"].(map[string]interface{}); ok {
+		if name, ok := getMapString("name", instancePool); ok {
+			model.InstancePoolName = types.StringValue(name)
+		}
+		if poolType, ok := getMapString("type", instancePool); ok {
+			model.InstancePoolType = types.StringValue(poolType)
+		}
+	}
+	if driverCores, ok := sparkCompute["driverCores"].(float64); ok {
+		model.DriverCores = types.Int64Value(int64(driverCores))
+	}
+	if driverMemory, ok := getMapString("driverMemory", sparkCompute); ok {
+		model.DriverMemory = types.StringValue(driverMemory)
+	}
+	if executorCores, ok := sparkCompute["executorCores"].(float64); ok {
+		model.ExecutorCores = types.Int64Value(int64(executorCores))
+	}
+	if executorMemory, ok := getMapString("executorMemory", sparkCompute); ok {
+		model.ExecutorMemory = types.StringValue(executorMemory)
+	}
+	if runtimeVersion, ok := getMapString("runtimeVersion", sparkCompute); ok {
+		model.RuntimeVersion = types.StringValue(runtimeVersion)
+	}
+
+	if allocation, ok := sparkCompute["dynamicExecutorAllocation"].(map[string]interface{}); ok && model.DynamicExecutorAllocation != nil {
+		if enabled, ok := allocation["enabled"].(bool); ok {
+			model.DynamicExecutorAllocation.Enabled = types.BoolValue(enabled)
+		}
+		if minExecutors, ok := allocation["minExecutors"].(float64); ok {
+			model.DynamicExecutorAllocation.MinExecutors = types.Int64Value(int64(minExecutors))
+		}
+		if maxExecutors, ok := allocation["maxExecutors"].(float64); ok {
+			model.DynamicExecutorAllocation.MaxExecutors = types.Int64Value(int64(maxExecutors))
+		}
+	}
+
+	if rawProperties, ok := sparkCompute["sparkProperties"].(map[string]interface{}); ok && (!model.SparkProperties.IsNull() || len(rawProperties) > 0) {
+		properties := make(map[string]string, len(rawProperties))
+		for key, value := range rawProperties {
+			properties[key] = fmt.Sprintf("%v", value)
+		}
+		model.SparkProperties, diags = types.MapValueFrom(ctx, types.StringType, properties)
+	}
+
+	return diags
+}
+
+// Helper function to create the environment.
+func (r *environmentResource) createEnvironment(workspaceID, displayName, description string) (string, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments", workspaceID)
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+	}
+
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create environment: %w", err)
+	}
+
+	environmentID, ok := responseBody["id"].(string)
+	if !ok || environmentID == "" {
+		return "", fmt.Errorf("expected field 'id' not found in response or is empty")
+	}
+
+	return environmentID, nil
+}
+
+// Helper function to read the environment.
+func (r *environmentResource) readEnvironment(workspaceID, environmentID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s", workspaceID, environmentID)
+	return r.client.Get(url)
+}
+
+// Helper function to update the display name and description of the environment.
+func (r *environmentResource) updateEnvironment(workspaceID, environmentID, displayName, description string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s", workspaceID, environmentID)
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+	}
+
+	_, err := r.client.Patch(url, body)
+	return err
+}
+
+// Helper function to delete the environment.
+func (r *environmentResource) deleteEnvironment(workspaceID, environmentID string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s", workspaceID, environmentID)
+	return r.client.Delete(url)
+}
+
+// Helper function to update the staging Spark compute of the environment.
+func (r *environmentResource) updateStagingSparkCompute(ctx context.Context, workspaceID, environmentID string, sparkCompute *environmentSparkComputeModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s/staging/sparkcompute", workspaceID, environmentID)
+	body := map[string]interface{}{
+		"instancePool": map[string]interface{}{
+			"name": sparkCompute.InstancePoolName.ValueString(),
+			"type": sparkCompute.InstancePoolType.ValueString(),
+		},
+		"driverCores":    sparkCompute.DriverCores.ValueInt64(),
+		"driverMemory":   sparkCompute.DriverMemory.ValueString(),
+		"executorCores":  sparkCompute.ExecutorCores.ValueInt64(),
+		"executorMemory": sparkCompute.ExecutorMemory.ValueString(),
+		"runtimeVersion": sparkCompute.RuntimeVersion.ValueString(),
+	}
+
+	if sparkCompute.DynamicExecutorAllocation != nil {
+		body["dynamicExecutorAllocation"] = map[string]interface{}{
+			"enabled":      sparkCompute.DynamicExecutorAllocation.Enabled.ValueBool(),
+			"minExecutors": sparkCompute.DynamicExecutorAllocation.MinExecutors.ValueInt64(),
+			"maxExecutors": sparkCompute.DynamicExecutorAllocation.MaxExecutors.ValueInt64(),
+		}
+	}
+
+	sparkProperties := map[string]string{}
+	if !sparkCompute.SparkProperties.IsNull() {
+		diags.Append(sparkCompute.SparkProperties.ElementsAs(ctx, &sparkProperties, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	body["sparkProperties"] = sparkProperties
+
+	if _, err := r.client.PatchBytes(url, body); err != nil {
+		diags.AddError(
+			"Error updating environment Spark compute",
+			"Could not update staging Spark compute: "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// defaultSparkCompute returns the Spark compute of a new environment: the default pool and runtime of the
+// workspace with medium nodes and no Spark properties.
+func (r *environmentResource) defaultSparkCompute(workspaceID string) (*environmentSparkComputeModel, error) {
+	settings, err := r.client.Get(fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/spark/settings", workspaceID))
+	if err != nil {
+		return nil, err
+	}
+	if errorCode, ok := getMapString("errorCode", settings); ok {
+		message, _ := getMapString("message", settings)
+		return nil, fmt.Errorf("%s: %s", errorCode, message)
+	}
+
+	pool, _ := settings["pool"].(map[string]interface{})
+	defaultPool, _ := pool["defaultPool"].(map[string]interface{})
+	poolName, ok := getMapString("name", defaultPool)
+	if !ok {
+		poolName = "Starter Pool"
+	}
+	poolType, ok := getMapString("type", defaultPool)
+	if !ok {
+		poolType = "Workspace"
+	}
+
+	environment, _ := settings["environment"].(map[string]interface{})
+	runtimeVersion, ok := getMapString("runtimeVersion", environment)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format: 'environment.runtimeVersion' key not found")
+	}
+
+	return &environmentSparkComputeModel{
+		InstancePoolName: types.StringValue(poolName),
+		InstancePoolType: types.StringValue(poolType),
+		DriverCores:      types.Int64Value(environmentDefaultNodeCores),
+		DriverMemory:     types.StringValue(environmentDefaultNodeMemory),
+		ExecutorCores:    types.Int64Value(environmentDefaultNodeCores),
+		ExecutorMemory:   types.StringValue(environmentDefaultNodeMemory),
+		RuntimeVersion:   types.StringValue(runtimeVersion),
+		SparkProperties:  types.MapNull(types.StringType),
+	}, nil
+}
+
+// Helper function to upload a library to the staging area of the environment.
+func (r *environmentResource) uploadStagingLibrary(workspaceID, environmentID string, library definitionPart) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s/staging/libraries", workspaceID, environmentID)
+	_, err := r.client.PostMultipartFile(url, "file", library.Path, library.Payload)
+	return err
+}
+
+// Helper function to delete a library from the staging area of the environment.
+func (r *environmentResource) deleteStagingLibrary(workspaceID, environmentID, libraryName string) error {
+	requestURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s/staging/libraries?libraryToDelete=%s", workspaceID, environmentID, url.QueryEscape(libraryName))
+	return r.client.Delete(requestURL)
+}
+
+// publishEnvironment publishes the staged changes and waits until the publish operation has finished.
+// The environment keeps reporting the previous publish for a moment, so the wait only ends once the details
+// show a different target version or a state other than the one before the publish.
+func (r *environmentResource) publishEnvironment(ctx context.Context, model *environmentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	workspaceID := model.WorkspaceID.ValueString()
+	environmentID := model.ID.ValueString()
+
+	environment, err := r.readEnvironment(workspaceID, environmentID)
+	if err != nil {
+		diags.AddError(
+			"Error publishing environment",
+			"Could not read environment publish state: "+err.Error(),
+		)
+		return diags
+	}
+	setEnvironmentPublishDetails(model, environment)
+	previousState := model.PublishState
+	previousTargetVersion := model.PublishTargetVersion
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/environments/%s/staging/publish", workspaceID, environmentID)
+	if _, err := r.client.Post(url, nil); err != nil {
+		diags.AddError(
+			"Error publishing environment",
+			"Could not start environment publish: "+err.Error(),
+		)
+		return diags
+	}
+
+	started := false
+	startDeadline := time.Now().Add(environmentPublishStartTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Error publishing environment",
+				"Timed out waiting for the environment publish to finish: "+ctx.Err().Error(),
+			)
+			return diags
+		case <-time.After(environmentPublishPollInterval):
+		}
+
+		environment, err := r.readEnvironment(workspaceID, environmentID)
+		if err != nil {
+			diags.AddError(
+				"Error publishing environment",
+				"Could not read environment publish state: "+err.Error(),
+			)
+			return diags
+		}
+		setEnvironmentPublishDetails(model, environment)
+
+		if !started {
+			started = !model.PublishTargetVersion.Equal(previousTargetVersion) || !model.PublishState.Equal(previousState)
+		}
+		if !started {
+			if time.Now().After(startDeadline) {
+				diags.AddError(
+					"Error publishing environment",
+					fmt.Sprintf("The environment still reports the previous publish (state %s) %s after publishing.", previousState.ValueString(), environmentPublishStartTimeout),
+				)
+				return diags
+			}
+			continue
+		}
+
+		switch model.PublishState.ValueString() {
+		case "Success":
+			return diags
+		case "Failed", "Cancelled":
+			diags.AddError(
+				"Error publishing environment",
+				fmt.Sprintf("Environment publish finished with state %s. Check the environment in the Fabric portal for details.", model.PublishState.ValueString()),
+			)
+			return diags
+		}
+	}
+}
@@ -11,6 +11,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &eventstreamResource{}
+	_ resource.ResourceWithModifyPlan     = &eventstreamResource{}
+)
+
 // Define the resource.
 type eventstreamResource struct {
 	client *apiclient.APIClient
@@ -18,24 +24,37 @@ type eventstreamResource struct {
 
 // Define the schema.
 func (r *eventstreamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed: true,
-			},
-			"workspace_id": schema.StringAttribute{
-				Required: true,
-			},
-			"name": schema.StringAttribute{
-				Required: true,
-			},
-			"description": schema.StringAttribute{
-				Required: true,
-			},
-			"last_updated": schema.StringAttribute{
-				Computed: true,
-			},
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"workspace_id": schema.StringAttribute{
+			Required: true,
+		},
+		"name": schema.StringAttribute{
+			Required: true,
+		},
+		"description": schema.StringAttribute{
+			Required: true,
+		},
+		"last_updated": schema.StringAttribute{
+			Computed: true,
+		},
+		"definition_hash": schema.StringAttribute{
+			Computed:    true,
+			Description: "SHA-256 hash of the eventstream.json generated from the sources, destinations and operators.",
 		},
+		"remote_definition_hash": schema.StringAttribute{
+			Computed:    true,
+			Description: "SHA-256 hash of the eventstream.json exported from Fabric after the last apply. Used to detect changes made outside of Terraform.",
+		},
+	}
+	for name, attribute := range eventstreamTopologySchema() {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
 	}
 }
 
@@ -46,6 +65,12 @@ type eventstreamResourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
 	LastUpdated types.String `tfsdk:"last_updated"`
+
+	Sources              []eventstreamSourceModel      `tfsdk:"sources"`
+	Destinations         []eventstreamDestinationModel `tfsdk:"destinations"`
+	Operators            []eventstreamOperatorModel    `tfsdk:"operators"`
+	DefinitionHash       types.String                  `tfsdk:"definition_hash"`
+	RemoteDefinitionHash types.String                  `tfsdk:"remote_definition_hash"`
 }
 
 // Implement Metadata method.
@@ -58,6 +83,75 @@ func NewEventStreamResource(client *apiclient.APIClient) resource.Resource {
 	return &eventstreamResource{client: client}
 }
 
+// ValidateConfig checks the sources, destinations and operators before they are sent to Fabric.
+func (r *eventstreamResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config eventstreamResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, topologyErr := range validateEventstreamTopology(config) {
+		resp.Diagnostics.AddAttributeError(topologyErr.Path, "Invalid eventstream topology", topologyErr.Message)
+	}
+}
+
+// ModifyPlan hashes the generated eventstream.json so that topology changes show up in the plan.
+func (r *eventstreamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan eventstreamResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without topology blocks the definition is not managed by Terraform.
+	if !hasEventstreamTopology(plan) {
+		plan.DefinitionHash = types.StringNull()
+		plan.RemoteDefinitionHash = types.StringNull()
+		diags = resp.Plan.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	if !eventstreamTopologyKnown(plan) {
+		return
+	}
+
+	parts, err := eventstreamDefinitionParts(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error building eventstream definition",
+			"Could not build eventstream definition: "+err.Error(),
+		)
+		return
+	}
+
+	var prior *definitionHashes
+	if !req.State.Raw.IsNull() {
+		var state eventstreamResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		stateHashes := state.definitionHashes()
+		prior = &stateHashes
+	}
+
+	// A changed topology, or one cleared by Read after drift, is uploaded again on apply.
+	plan.setDefinitionHashes(planDefinitionHashes(parts, plan.definitionHashes(), prior))
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
 // Implement CRUD operations.
 func (r *eventstreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
@@ -68,8 +162,22 @@ func (r *eventstreamResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Build the topology definition, if any.
+	var parts []definitionPart
+	if hasEventstreamTopology(plan) {
+		var err error
+		parts, err = eventstreamDefinitionParts(plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error building eventstream definition",
+				"Could not build eventstream definition: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// Create event stream.
-	eventStreamID, err := r.createEventStream(plan.WorkspaceID.ValueString(), plan.Name.ValueString(), plan.Description.ValueString())
+	eventStreamID, err := r.createEventStream(plan.WorkspaceID.ValueString(), plan.Name.ValueString(), plan.Description.ValueString(), parts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating event stream",
@@ -82,6 +190,22 @@ func (r *eventstreamResource) Create(ctx context.Context, req resource.CreateReq
 	plan.ID = types.StringValue(eventStreamID)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// Record the definition as stored by Fabric for drift detection.
+	plan.DefinitionHash = types.StringNull()
+	plan.RemoteDefinitionHash = types.StringNull()
+	if parts != nil {
+		plan.DefinitionHash = types.StringValue(hashDefinitionParts(parts))
+		remoteHash, err := remoteDefinitionHash(r.client, plan.WorkspaceID.ValueString(), plan.ID.ValueString(), "", eventstreamDefinitionPath)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error exporting eventstream definition",
+				"Could not export eventstream definition: "+err.Error(),
+			)
+			return
+		}
+		plan.RemoteDefinitionHash = types.StringValue(remoteHash)
+	}
+
 	// Set state.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -123,6 +247,20 @@ func (r *eventstreamResource) Read(ctx context.Context, req resource.ReadRequest
 	state.Name = types.StringValue(name)
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// Compare the exported topology with the one recorded after the last apply.
+	if hasEventstreamTopology(state) {
+		hashes := state.definitionHashes()
+		err = refreshDefinitionHashes(r.client, state.WorkspaceID.ValueString(), state.ID.ValueString(), "", &hashes, eventstreamDefinitionPath)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error exporting eventstream definition",
+				"Could not export eventstream definition: "+err.Error(),
+			)
+			return
+		}
+		state.setDefinitionHashes(hashes)
+	}
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -161,6 +299,30 @@ func (r *eventstreamResource) Update(ctx context.Context, req resource.UpdateReq
 	plan.ID = state.ID // Ensure the ID remains unchanged.
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// Upload the topology when it changed locally or in Fabric.
+	plan.DefinitionHash = types.StringNull()
+	plan.RemoteDefinitionHash = types.StringNull()
+	if hasEventstreamTopology(plan) {
+		parts, err := eventstreamDefinitionParts(plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error building eventstream definition",
+				"Could not build eventstream definition: "+err.Error(),
+			)
+			return
+		}
+
+		hashes, err := applyDefinition(r.client, state.WorkspaceID.ValueString(), state.ID.ValueString(), "", parts, state.definitionHashes(), eventstreamDefinitionPath)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating eventstream definition",
+				"Could not update eventstream definition: "+err.Error(),
+			)
+			return
+		}
+		plan.setDefinitionHashes(hashes)
+	}
+
 	// Set state.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -194,14 +356,18 @@ func (r *eventstreamResource) Delete(ctx context.Context, req resource.DeleteReq
 
 // Helper functions for event stream operations.
 
-func (r *eventstreamResource) createEventStream(workspaceID, name, description string) (string, error) {
+func (r *eventstreamResource) createEventStream(workspaceID, name, description string, parts []definitionPart) (string, error) {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/eventstreams", workspaceID)
 	body := map[string]interface{}{
 		"displayName": name,
 		"description": description,
 	}
+	if parts != nil {
+		body["definition"] = definitionBody("", parts)
+	}
 
-	responseBody, err := r.client.PostWithOperationCheck(url, body)
+	// Creating an eventstream with a definition runs as a long-running operation.
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
 	if err != nil {
 		return "", err
 	}
@@ -217,6 +383,22 @@ func (r *eventstreamResource) createEventStream(workspaceID, name, description s
 	return eventStreamID, nil
 }
 
+// definitionHashes returns the computed definition attributes of the model.
+func (m eventstreamResourceModel) definitionHashes() definitionHashes {
+	return definitionHashes{
+		DefinitionHash:       m.DefinitionHash,
+		RemoteDefinitionHash: m.RemoteDefinitionHash,
+		LastUpdated:          m.LastUpdated,
+	}
+}
+
+// setDefinitionHashes stores the computed definition attributes in the model.
+func (m *eventstreamResourceModel) setDefinitionHashes(hashes definitionHashes) {
+	m.DefinitionHash = hashes.DefinitionHash
+	m.RemoteDefinitionHash = hashes.RemoteDefinitionHash
+	m.LastUpdated = hashes.LastUpdated
+}
+
 func (r *eventstreamResource) readEventStream(workspaceID, eventStreamID string) (map[string]interface{}, error) {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/eventstreams/%s", workspaceID, eventStreamID)
 	return r.client.Get(url)
@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"terraform-provider-microsoftfabric/internal/apiclient"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -36,17 +38,39 @@ func (r *eventhouseResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"last_updated": schema.StringAttribute{
 				Computed: true, // Timestamp of the last update to the Eventhouse.
 			},
+			"minimum_consumption_units": schema.Float64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Minimum consumption units kept always available, e.g. 2.25 or 4.25. Set 0 to disable always-on capacity.",
+			},
+			"query_service_uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "The query service URI of the Eventhouse.",
+			},
+			"ingestion_service_uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ingestion service URI of the Eventhouse.",
+			},
+			"database_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the KQL databases in the Eventhouse.",
+			},
 		},
 	}
 }
 
 // eventhouseResourceModel defines the model for managing the Eventhouse's state.
 type eventhouseResourceModel struct {
-	ID          types.String `tfsdk:"id"`           // Unique identifier for the Eventhouse
-	WorkspaceID types.String `tfsdk:"workspace_id"` // ID of the workspace the Eventhouse belongs to
-	DisplayName types.String `tfsdk:"display_name"` // Display name of the Eventhouse
-	Description types.String `tfsdk:"description"`  // Description of the Eventhouse
-	LastUpdated types.String `tfsdk:"last_updated"` // Timestamp of when the Eventhouse was last updated
+	ID                      types.String  `tfsdk:"id"`                        // Unique identifier for the Eventhouse
+	WorkspaceID             types.String  `tfsdk:"workspace_id"`              // ID of the workspace the Eventhouse belongs to
+	DisplayName             types.String  `tfsdk:"display_name"`              // Display name of the Eventhouse
+	Description             types.String  `tfsdk:"description"`               // Description of the Eventhouse
+	LastUpdated             types.String  `tfsdk:"last_updated"`              // Timestamp of when the Eventhouse was last updated
+	MinimumConsumptionUnits types.Float64 `tfsdk:"minimum_consumption_units"` // Always-on capacity
+	QueryServiceURI         types.String  `tfsdk:"query_service_uri"`         // Query service URI
+	IngestionServiceURI     types.String  `tfsdk:"ingestion_service_uri"`     // Ingestion service URI
+	DatabaseIDs             types.List    `tfsdk:"database_ids"`              // IDs of the child KQL databases
 }
 
 // Metadata returns metadata about the Eventhouse resource.
@@ -70,7 +94,7 @@ func (r *eventhouseResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Create the Eventhouse via the API client
-	eventhouseID, err := r.createEventhouse(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString())
+	eventhouseID, err := r.createEventhouse(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString(), plan.MinimumConsumptionUnits)
 	if err != nil {
 		// Add error diagnostics if creation fails
 		resp.Diagnostics.AddError(
@@ -84,6 +108,20 @@ func (r *eventhouseResource) Create(ctx context.Context, req resource.CreateRequ
 	plan.ID = types.StringValue(eventhouseID)                            // Set the ID returned from the API
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850)) // Current timestamp
 
+	// Read back the computed properties
+	eventhouse, err := r.readEventhouse(plan.WorkspaceID.ValueString(), eventhouseID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Eventhouse",
+			"Could not read Eventhouse: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setEventhouseProperties(ctx, &plan, eventhouse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set the state of the resource in Terraform
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -105,6 +143,12 @@ func (r *eventhouseResource) Read(ctx context.Context, req resource.ReadRequest,
 	// Read the Eventhouse details from the API
 	eventhouse, err := r.readEventhouse(state.WorkspaceID.ValueString(), state.ID.ValueString())
 	if err != nil {
+		// The Eventhouse was deleted outside of Terraform
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		// Add error diagnostics if reading fails
 		resp.Diagnostics.AddError(
 			"Error reading Eventhouse",
@@ -113,10 +157,26 @@ func (r *eventhouseResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	displayName, ok := eventhouse["displayName"].(string)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error reading Eventhouse",
+			"Unexpected response format: 'displayName' key not found or not a string",
+		)
+		return
+	}
+	description, _ := eventhouse["description"].(string) // description is optional
+
 	// Update state with the values retrieved from the API
-	state.DisplayName = types.StringValue(eventhouse.DisplayName.ValueString())
-	state.Description = types.StringValue(eventhouse.Description.ValueString())
+	state.DisplayName = types.StringValue(displayName)
+	if !state.Description.IsNull() || description != "" {
+		state.Description = types.StringValue(description)
+	}
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850)) // Update last modified timestamp
+	resp.Diagnostics.Append(setEventhouseProperties(ctx, &state, eventhouse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -152,10 +212,36 @@ func (r *eventhouseResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// Change the always-on capacity only when it was changed in the configuration
+	if !plan.MinimumConsumptionUnits.IsUnknown() && !plan.MinimumConsumptionUnits.Equal(state.MinimumConsumptionUnits) {
+		err := r.updateMinimumConsumptionUnits(state.WorkspaceID.ValueString(), state.ID.ValueString(), plan.MinimumConsumptionUnits.ValueFloat64())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Eventhouse",
+				"Could not update minimum consumption units: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// Set LastUpdated field to current timestamp
 	plan.ID = state.ID // Ensure the ID remains unchanged
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// Read back the computed properties
+	eventhouse, err := r.readEventhouse(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Eventhouse",
+			"Could not read Eventhouse: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setEventhouseProperties(ctx, &plan, eventhouse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set the updated state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -188,17 +274,47 @@ func (r *eventhouseResource) Delete(ctx context.Context, req resource.DeleteRequ
 	resp.State.RemoveResource(ctx)
 }
 
+// setEventhouseProperties copies the computed Eventhouse properties from an API response into the model.
+func setEventhouseProperties(ctx context.Context, model *eventhouseResourceModel, eventhouse map[string]interface{}) diag.Diagnostics {
+	properties, _ := eventhouse["properties"].(map[string]interface{})
+
+	queryServiceURI, _ := getMapString("queryServiceUri", properties)
+	ingestionServiceURI, _ := getMapString("ingestionServiceUri", properties)
+	model.QueryServiceURI = types.StringValue(queryServiceURI)
+	model.IngestionServiceURI = types.StringValue(ingestionServiceURI)
+
+	minimumConsumptionUnits, _ := properties["minimumConsumptionUnits"].(float64)
+	model.MinimumConsumptionUnits = types.Float64Value(minimumConsumptionUnits)
+
+	databaseIDs := []string{}
+	rawDatabaseIDs, _ := properties["databasesItemIds"].([]interface{})
+	for _, rawDatabaseID := range rawDatabaseIDs {
+		if databaseID, ok := rawDatabaseID.(string); ok {
+			databaseIDs = append(databaseIDs, databaseID)
+		}
+	}
+
+	var diags diag.Diagnostics
+	model.DatabaseIDs, diags = types.ListValueFrom(ctx, types.StringType, databaseIDs)
+	return diags
+}
+
 // createEventhouse sends a request to create a new Eventhouse in the specified workspace.
-func (r *eventhouseResource) createEventhouse(workspaceID, displayName, description string) (string, error) {
+func (r *eventhouseResource) createEventhouse(workspaceID, displayName, description string, minimumConsumptionUnits types.Float64) (string, error) {
 	// URL for the API endpoint to create an Eventhouse
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/eventhouses", workspaceID)
 	body := map[string]interface{}{
 		"displayName": displayName, // Set the display name
 		"description": description, // Set the description
 	}
+	if !minimumConsumptionUnits.IsUnknown() && !minimumConsumptionUnits.IsNull() {
+		body["creationPayload"] = map[string]interface{}{
+			"minimumConsumptionUnits": minimumConsumptionUnits.ValueFloat64(),
+		}
+	}
 
-	// Make a POST request to create the Eventhouse
-	responseBody, err := r.client.Post(url, body) // Change from PostWithOperationCheck to Post
+	// Make a POST request to create the Eventhouse and wait for the provisioning to finish
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
 	if err != nil {
 		return "", fmt.Errorf("failed to make POST request: %w", err) // Return the error on failure
 	}
@@ -213,23 +329,10 @@ func (r *eventhouseResource) createEventhouse(workspaceID, displayName, descript
 }
 
 // readEventhouse retrieves the details of an existing Eventhouse.
-func (r *eventhouseResource) readEventhouse(workspaceID, eventhouseID string) (eventhouseResourceModel, error) {
+func (r *eventhouseResource) readEventhouse(workspaceID, eventhouseID string) (map[string]interface{}, error) {
 	// URL for the API endpoint to read an Eventhouse
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/eventhouses/%s", workspaceID, eventhouseID)
-
-	responseBody, err := r.client.Get(url) // Fetch Eventhouse details from API
-	if err != nil {
-		return eventhouseResourceModel{}, err // Return error if fetching fails
-	}
-
-	// Map the response data to the Eventhouse model
-	eventhouse := eventhouseResourceModel{
-		ID:          types.StringValue(responseBody["id"].(string)),          // Eventhouse ID
-		DisplayName: types.StringValue(responseBody["displayName"].(string)), // Eventhouse display name
-		Description: types.StringValue(responseBody["description"].(string)), // Eventhouse description
-	}
-
-	return eventhouse, nil // Return the populated Eventhouse model
+	return r.client.Get(url) // Fetch Eventhouse details from API
 }
 
 // updateEventhouse sends a request to update an existing Eventhouse.
@@ -245,6 +348,38 @@ func (r *eventhouseResource) updateEventhouse(workspaceID, eventhouseID, display
 	return err                          // Return any error encountered
 }
 
+// updateMinimumConsumptionUnits changes the always-on capacity through EventhouseProperties.json.
+// The part is exported first and only the capacity is changed, so the other eventhouse properties are kept.
+func (r *eventhouseResource) updateMinimumConsumptionUnits(workspaceID, eventhouseID string, minimumConsumptionUnits float64) error {
+	exported, err := getItemDefinition(r.client, workspaceID, eventhouseID, "")
+	if err != nil {
+		return fmt.Errorf("failed to export the eventhouse definition: %w", err)
+	}
+
+	var properties map[string]interface{}
+	for _, part := range exported {
+		if part.Path != "EventhouseProperties.json" {
+			continue
+		}
+		if err := json.Unmarshal(part.Payload, &properties); err != nil {
+			return fmt.Errorf("failed to parse EventhouseProperties.json: %w", err)
+		}
+	}
+
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	properties["minimumConsumptionUnits"] = minimumConsumptionUnits
+
+	payload, err := json.Marshal(properties)
+	if err != nil {
+		return fmt.Errorf("failed to marshal EventhouseProperties.json: %w", err)
+	}
+
+	parts := []definitionPart{{Path: "EventhouseProperties.json", Payload: payload}}
+	return updateItemDefinition(r.client, workspaceID, eventhouseID, "", parts)
+}
+
 // deleteEventhouse sends a request to delete an existing Eventhouse.
 func (r *eventhouseResource) deleteEventhouse(workspaceID, eventhouseID string) error {
 	// URL for the API endpoint to delete an Eventhouse
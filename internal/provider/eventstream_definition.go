@@ -0,0 +1,572 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// eventstreamDefinitionPath is the definition part that holds the eventstream topology.
+const eventstreamDefinitionPath = "eventstream.json"
+
+// Node types supported by the typed eventstream blocks, mapped to their names in eventstream.json.
+var (
+	eventstreamSourceTypes = map[string]string{
+		"EventHub":       "AzureEventHub",
+		"IoTHub":         "AzureIoTHub",
+		"CustomEndpoint": "CustomEndpoint",
+		"SampleData":     "SampleData",
+	}
+	eventstreamDestinationTypes = map[string]string{
+		"Lakehouse":  "Lakehouse",
+		"Eventhouse": "Eventhouse",
+		"Activator":  "Activator",
+	}
+	eventstreamOperatorTypes = map[string]string{
+		"Filter":    "Filter",
+		"Aggregate": "Aggregate",
+		"GroupBy":   "GroupBy",
+		"Union":     "Union",
+	}
+)
+
+// Models of the eventstream topology blocks.
+type eventstreamSourceModel struct {
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"type"`
+	DataConnectionID types.String `tfsdk:"data_connection_id"`
+	ConsumerGroup    types.String `tfsdk:"consumer_group"`
+	SampleDataType   types.String `tfsdk:"sample_data_type"`
+}
+
+type eventstreamDestinationModel struct {
+	Name               types.String   `tfsdk:"name"`
+	Type               types.String   `tfsdk:"type"`
+	InputNodes         []types.String `tfsdk:"input_nodes"`
+	WorkspaceID        types.String   `tfsdk:"workspace_id"`
+	ItemID             types.String   `tfsdk:"item_id"`
+	DatabaseName       types.String   `tfsdk:"database_name"`
+	Schema             types.String   `tfsdk:"schema"`
+	TableName          types.String   `tfsdk:"table_name"`
+	MinimumRows        types.Int64    `tfsdk:"minimum_rows"`
+	MaxDurationSeconds types.Int64    `tfsdk:"max_duration_seconds"`
+}
+
+type eventstreamOperatorModel struct {
+	Name                  types.String                  `tfsdk:"name"`
+	Type                  types.String                  `tfsdk:"type"`
+	InputNodes            []types.String                `tfsdk:"input_nodes"`
+	FilterColumn          types.String                  `tfsdk:"filter_column"`
+	FilterOperator        types.String                  `tfsdk:"filter_operator"`
+	FilterValue           types.String                  `tfsdk:"filter_value"`
+	FilterValueType       types.String                  `tfsdk:"filter_value_type"`
+	Aggregations          []eventstreamAggregationModel `tfsdk:"aggregations"`
+	GroupByColumns        []types.String                `tfsdk:"group_by_columns"`
+	WindowType            types.String                  `tfsdk:"window_type"`
+	WindowDurationSeconds types.Int64                   `tfsdk:"window_duration_seconds"`
+}
+
+type eventstreamAggregationModel struct {
+	Function types.String `tfsdk:"function"`
+	Column   types.String `tfsdk:"column"`
+	Alias    types.String `tfsdk:"alias"`
+}
+
+// eventstreamTopologySchema returns the schema attributes of the sources, destinations and operators blocks.
+func eventstreamTopologySchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"sources": schema.ListNestedAttribute{
+			Optional:    true,
+			Description: "Sources of the eventstream. All sources feed the default stream, which is named '<name>-stream'.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "Unique name of the source node.",
+					},
+					"type": schema.StringAttribute{
+						Required:    true,
+						Description: "Type of the source. Available options are 'EventHub', 'IoTHub', 'CustomEndpoint' and 'SampleData'.",
+					},
+					"data_connection_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ID of the Fabric connection to the Event Hub or IoT Hub. Required for 'EventHub' and 'IoTHub' sources.",
+					},
+					"consumer_group": schema.StringAttribute{
+						Optional:    true,
+						Description: "Consumer group of the Event Hub or IoT Hub. Defaults to '$Default'.",
+					},
+					"sample_data_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Sample data set of a 'SampleData' source, for example 'Bicycles', 'YellowTaxi' or 'StockMarket'.",
+					},
+				},
+			},
+		},
+		"destinations": schema.ListNestedAttribute{
+			Optional:    true,
+			Description: "Destinations of the eventstream.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "Unique name of the destination node.",
+					},
+					"type": schema.StringAttribute{
+						Required:    true,
+						Description: "Type of the destination. Available options are 'Lakehouse', 'Eventhouse' and 'Activator'.",
+					},
+					"input_nodes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Names of the nodes feeding the destination. Defaults to the default stream.",
+					},
+					"workspace_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ID of the workspace of the destination item. Defaults to the workspace of the eventstream.",
+					},
+					"item_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The ID of the lakehouse, KQL database or Activator receiving the events.",
+					},
+					"database_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "Name of the KQL database. Required for 'Eventhouse' destinations.",
+					},
+					"schema": schema.StringAttribute{
+						Optional:    true,
+						Description: "Schema of the lakehouse table. Only used by 'Lakehouse' destinations of schema enabled lakehouses.",
+					},
+					"table_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "Name of the target table. Required for 'Lakehouse' and 'Eventhouse' destinations.",
+					},
+					"minimum_rows": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Minimum number of rows per file written to the lakehouse. Defaults to 100000.",
+					},
+					"max_duration_seconds": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Maximum time in seconds before a file is written to the lakehouse. Defaults to 120.",
+					},
+				},
+			},
+		},
+		"operators": schema.ListNestedAttribute{
+			Optional:    true,
+			Description: "Event processing operators of the eventstream.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "Unique name of the operator node.",
+					},
+					"type": schema.StringAttribute{
+						Required:    true,
+						Description: "Type of the operator. Available options are 'Filter', 'Aggregate', 'GroupBy' and 'Union'.",
+					},
+					"input_nodes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Names of the nodes feeding the operator. Defaults to the default stream. Required for 'Union' operators, which need at least two.",
+					},
+					"filter_column": schema.StringAttribute{
+						Optional:    true,
+						Description: "Column compared by a 'Filter' operator.",
+					},
+					"filter_operator": schema.StringAttribute{
+						Optional:    true,
+						Description: "Comparison of a 'Filter' operator, for example 'Equals', 'NotEquals', 'GreaterThan' or 'Contains'.",
+					},
+					"filter_value": schema.StringAttribute{
+						Optional:    true,
+						Description: "Value compared by a 'Filter' operator.",
+					},
+					"filter_value_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Data type of filter_value. Defaults to 'Nvarchar(max)'; use 'BigInt' or 'Float' for numbers.",
+					},
+					"aggregations": schema.ListNestedAttribute{
+						Optional:    true,
+						Description: "Aggregations of an 'Aggregate' or 'GroupBy' operator.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"function": schema.StringAttribute{
+									Required:    true,
+									Description: "Aggregate function, for example 'Sum', 'Average', 'Minimum', 'Maximum' or 'Count'.",
+								},
+								"column": schema.StringAttribute{
+									Required:    true,
+									Description: "Column to aggregate.",
+								},
+								"alias": schema.StringAttribute{
+									Required:    true,
+									Description: "Name of the output column.",
+								},
+							},
+						},
+					},
+					"group_by_columns": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Columns a 'GroupBy' operator groups by.",
+					},
+					"window_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Time window of a 'GroupBy' operator. Available options are 'Tumbling', 'Hopping', 'Sliding', 'Session' and 'Snapshot'.",
+					},
+					"window_duration_seconds": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Length in seconds of the time window of an 'Aggregate' or 'GroupBy' operator.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// hasEventstreamTopology reports whether any topology block is configured.
+func hasEventstreamTopology(model eventstreamResourceModel) bool {
+	return model.Sources != nil || model.Destinations != nil || model.Operators != nil
+}
+
+// eventstreamTopologyKnown reports whether every value of the topology blocks is known.
+// Destination items are often created in the same apply, so their IDs may be unknown during plan.
+func eventstreamTopologyKnown(model eventstreamResourceModel) bool {
+	values := []types.String{model.WorkspaceID, model.Name}
+	for _, source := range model.Sources {
+		values = append(values, source.Name, source.Type, source.DataConnectionID, source.ConsumerGroup, source.SampleDataType)
+	}
+	for _, destination := range model.Destinations {
+		values = append(values, destination.Name, destination.Type, destination.WorkspaceID, destination.ItemID, destination.DatabaseName, destination.Schema, destination.TableName)
+		values = append(values, destination.InputNodes...)
+		if destination.MinimumRows.IsUnknown() || destination.MaxDurationSeconds.IsUnknown() {
+			return false
+		}
+	}
+	for _, operator := range model.Operators {
+		values = append(values, operator.Name, operator.Type, operator.FilterColumn, operator.FilterOperator, operator.FilterValue, operator.FilterValueType, operator.WindowType)
+		values = append(values, operator.InputNodes...)
+		values = append(values, operator.GroupByColumns...)
+		for _, aggregation := range operator.Aggregations {
+			values = append(values, aggregation.Function, aggregation.Column, aggregation.Alias)
+		}
+		if operator.WindowDurationSeconds.IsUnknown() {
+			return false
+		}
+	}
+
+	for _, value := range values {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// eventstreamTopologyError describes an invalid topology block.
+type eventstreamTopologyError struct {
+	Path    path.Path
+	Message string
+}
+
+// validateEventstreamTopology checks the type specific settings and node references of the topology blocks.
+func validateEventstreamTopology(model eventstreamResourceModel) []eventstreamTopologyError {
+	var errs []eventstreamTopologyError
+	addError := func(p path.Path, format string, args ...interface{}) {
+		errs = append(errs, eventstreamTopologyError{Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Node names must be unique across sources, operators and destinations; the default stream is a node too.
+	nodes := map[string]struct{}{}
+	if !model.Name.IsUnknown() {
+		nodes[eventstreamDefaultStreamName(model)] = struct{}{}
+	}
+	addNode := func(p path.Path, name types.String) {
+		if name.IsUnknown() {
+			return
+		}
+		if _, exists := nodes[name.ValueString()]; exists {
+			addError(p.AtName("name"), "The node name %q is used more than once.", name.ValueString())
+		}
+		nodes[name.ValueString()] = struct{}{}
+	}
+
+	for i, source := range model.Sources {
+		p := path.Root("sources").AtListIndex(i)
+		addNode(p, source.Name)
+		if source.Type.IsUnknown() {
+			continue
+		}
+
+		switch source.Type.ValueString() {
+		case "EventHub", "IoTHub":
+			if source.DataConnectionID.IsNull() {
+				addError(p.AtName("data_connection_id"), "data_connection_id is required for %s sources.", source.Type.ValueString())
+			}
+		case "SampleData":
+			if source.SampleDataType.IsNull() {
+				addError(p.AtName("sample_data_type"), "sample_data_type is required for SampleData sources.")
+			}
+		case "CustomEndpoint":
+		default:
+			addError(p.AtName("type"), "Unsupported source type %q. Available options are 'EventHub', 'IoTHub', 'CustomEndpoint' and 'SampleData'.", source.Type.ValueString())
+		}
+	}
+
+	for i, operator := range model.Operators {
+		p := path.Root("operators").AtListIndex(i)
+		addNode(p, operator.Name)
+		if operator.Type.IsUnknown() {
+			continue
+		}
+
+		switch operator.Type.ValueString() {
+		case "Filter":
+			if operator.FilterColumn.IsNull() || operator.FilterOperator.IsNull() || operator.FilterValue.IsNull() {
+				addError(p, "filter_column, filter_operator and filter_value are required for Filter operators.")
+			}
+		case "Aggregate":
+			if len(operator.Aggregations) == 0 || operator.WindowDurationSeconds.IsNull() {
+				addError(p, "aggregations and window_duration_seconds are required for Aggregate operators.")
+			}
+		case "GroupBy":
+			if len(operator.Aggregations) == 0 || operator.WindowType.IsNull() || operator.WindowDurationSeconds.IsNull() {
+				addError(p, "aggregations, window_type and window_duration_seconds are required for GroupBy operators.")
+			}
+		case "Union":
+			if len(operator.InputNodes) < 2 {
+				addError(p.AtName("input_nodes"), "Union operators need at least two input nodes.")
+			}
+		default:
+			addError(p.AtName("type"), "Unsupported operator type %q. Available options are 'Filter', 'Aggregate', 'GroupBy' and 'Union'.", operator.Type.ValueString())
+		}
+	}
+
+	for i, destination := range model.Destinations {
+		p := path.Root("destinations").AtListIndex(i)
+		addNode(p, destination.Name)
+		if destination.Type.IsUnknown() {
+			continue
+		}
+
+		switch destination.Type.ValueString() {
+		case "Lakehouse":
+			if destination.TableName.IsNull() {
+				addError(p.AtName("table_name"), "table_name is required for Lakehouse destinations.")
+			}
+		case "Eventhouse":
+			if destination.DatabaseName.IsNull() || destination.TableName.IsNull() {
+				addError(p, "database_name and table_name are required for Eventhouse destinations.")
+			}
+		case "Activator":
+		default:
+			addError(p.AtName("type"), "Unsupported destination type %q. Available options are 'Lakehouse', 'Eventhouse' and 'Activator'.", destination.Type.ValueString())
+		}
+	}
+
+	// Every input node must refer to a source, operator or the default stream.
+	checkInputs := func(p path.Path, inputs []types.String) {
+		for j, input := range inputs {
+			if input.IsUnknown() {
+				continue
+			}
+			if _, exists := nodes[input.ValueString()]; !exists {
+				addError(p.AtName("input_nodes").AtListIndex(j), "The input node %q does not exist in the eventstream.", input.ValueString())
+			}
+		}
+	}
+	if !model.Name.IsUnknown() {
+		for i, operator := range model.Operators {
+			checkInputs(path.Root("operators").AtListIndex(i), operator.InputNodes)
+		}
+		for i, destination := range model.Destinations {
+			checkInputs(path.Root("destinations").AtListIndex(i), destination.InputNodes)
+		}
+	}
+
+	return errs
+}
+
+// eventstreamDefaultStreamName returns the name of the default stream all sources feed into.
+func eventstreamDefaultStreamName(model eventstreamResourceModel) string {
+	return model.Name.ValueString() + "-stream"
+}
+
+// eventstreamDefinitionParts builds the eventstream.json definition part from the topology blocks.
+func eventstreamDefinitionParts(model eventstreamResourceModel) ([]definitionPart, error) {
+	defaultStream := eventstreamDefaultStreamName(model)
+	inputSerialization := map[string]interface{}{
+		"type": "Json",
+		"properties": map[string]interface{}{
+			"encoding": "UTF8",
+		},
+	}
+	inputNodes := func(inputs []types.String) []map[string]interface{} {
+		if len(inputs) == 0 {
+			return []map[string]interface{}{{"name": defaultStream}}
+		}
+		nodes := make([]map[string]interface{}, 0, len(inputs))
+		for _, input := range inputs {
+			nodes = append(nodes, map[string]interface{}{"name": input.ValueString()})
+		}
+		return nodes
+	}
+	stringOrDefault := func(value types.String, defaultValue string) string {
+		if value.IsNull() || value.ValueString() == "" {
+			return defaultValue
+		}
+		return value.ValueString()
+	}
+	int64OrDefault := func(value types.Int64, defaultValue int64) int64 {
+		if value.IsNull() {
+			return defaultValue
+		}
+		return value.ValueInt64()
+	}
+
+	sources := make([]map[string]interface{}, 0, len(model.Sources))
+	streamInputs := make([]map[string]interface{}, 0, len(model.Sources))
+	for _, source := range model.Sources {
+		properties := map[string]interface{}{}
+		switch source.Type.ValueString() {
+		case "EventHub", "IoTHub":
+			properties["dataConnectionId"] = source.DataConnectionID.ValueString()
+			properties["consumerGroupName"] = stringOrDefault(source.ConsumerGroup, "$Default")
+			properties["inputSerialization"] = inputSerialization
+		case "SampleData":
+			properties["type"] = source.SampleDataType.ValueString()
+		}
+
+		sources = append(sources, map[string]interface{}{
+			"name":       source.Name.ValueString(),
+			"type":       eventstreamSourceTypes[source.Type.ValueString()],
+			"properties": properties,
+		})
+		streamInputs = append(streamInputs, map[string]interface{}{"name": source.Name.ValueString()})
+	}
+
+	streams := []map[string]interface{}{
+		{
+			"name":       defaultStream,
+			"type":       "DefaultStream",
+			"properties": map[string]interface{}{},
+			"inputNodes": streamInputs,
+		},
+	}
+
+	columnReference := func(column string) map[string]interface{} {
+		return map[string]interface{}{
+			"expressionType":     "ColumnReference",
+			"node":               nil,
+			"columnName":         column,
+			"columnPathSegments": []interface{}{},
+		}
+	}
+	duration := func(seconds int64) map[string]interface{} {
+		return map[string]interface{}{"value": seconds, "unit": "Second"}
+	}
+
+	operators := make([]map[string]interface{}, 0, len(model.Operators))
+	for _, operator := range model.Operators {
+		properties := map[string]interface{}{}
+
+		aggregations := make([]map[string]interface{}, 0, len(operator.Aggregations))
+		for _, aggregation := range operator.Aggregations {
+			aggregations = append(aggregations, map[string]interface{}{
+				"aggregateFunction": aggregation.Function.ValueString(),
+				"column":            columnReference(aggregation.Column.ValueString()),
+				"alias":             aggregation.Alias.ValueString(),
+			})
+		}
+
+		switch operator.Type.ValueString() {
+		case "Filter":
+			properties["conditions"] = []map[string]interface{}{
+				{
+					"column":       columnReference(operator.FilterColumn.ValueString()),
+					"operatorType": operator.FilterOperator.ValueString(),
+					"value": map[string]interface{}{
+						"expressionType": "Literal",
+						"dataType":       stringOrDefault(operator.FilterValueType, "Nvarchar(max)"),
+						"value":          operator.FilterValue.ValueString(),
+					},
+				},
+			}
+		case "Aggregate":
+			for _, aggregation := range aggregations {
+				aggregation["partitionBy"] = []interface{}{}
+				aggregation["duration"] = duration(operator.WindowDurationSeconds.ValueInt64())
+			}
+			properties["aggregations"] = aggregations
+		case "GroupBy":
+			groupBy := make([]map[string]interface{}, 0, len(operator.GroupByColumns))
+			for _, column := range operator.GroupByColumns {
+				groupBy = append(groupBy, columnReference(column.ValueString()))
+			}
+			properties["aggregations"] = aggregations
+			properties["groupBy"] = groupBy
+			properties["window"] = map[string]interface{}{
+				"type": operator.WindowType.ValueString(),
+				"properties": map[string]interface{}{
+					"duration": duration(operator.WindowDurationSeconds.ValueInt64()),
+					"offset":   duration(0),
+				},
+			}
+		}
+
+		operators = append(operators, map[string]interface{}{
+			"name":         operator.Name.ValueString(),
+			"type":         eventstreamOperatorTypes[operator.Type.ValueString()],
+			"inputNodes":   inputNodes(operator.InputNodes),
+			"properties":   properties,
+			"inputSchemas": []interface{}{},
+		})
+	}
+
+	destinations := make([]map[string]interface{}, 0, len(model.Destinations))
+	for _, destination := range model.Destinations {
+		properties := map[string]interface{}{
+			"workspaceId":        stringOrDefault(destination.WorkspaceID, model.WorkspaceID.ValueString()),
+			"itemId":             destination.ItemID.ValueString(),
+			"inputSerialization": inputSerialization,
+		}
+
+		switch destination.Type.ValueString() {
+		case "Lakehouse":
+			properties["schema"] = destination.Schema.ValueString()
+			properties["deltaTable"] = destination.TableName.ValueString()
+			properties["minimumRows"] = int64OrDefault(destination.MinimumRows, 100000)
+			properties["maximumDurationInSeconds"] = int64OrDefault(destination.MaxDurationSeconds, 120)
+		case "Eventhouse":
+			properties["dataIngestionMode"] = "ProcessedIngestion"
+			properties["databaseName"] = destination.DatabaseName.ValueString()
+			properties["tableName"] = destination.TableName.ValueString()
+		}
+
+		destinations = append(destinations, map[string]interface{}{
+			"name":       destination.Name.ValueString(),
+			"type":       eventstreamDestinationTypes[destination.Type.ValueString()],
+			"properties": properties,
+			"inputNodes": inputNodes(destination.InputNodes),
+		})
+	}
+
+	definition := map[string]interface{}{
+		"sources":            sources,
+		"destinations":       destinations,
+		"streams":            streams,
+		"operators":          operators,
+		"compatibilityLevel": "1.0",
+	}
+
+	payload, err := json.MarshalIndent(definition, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", eventstreamDefinitionPath, err)
+	}
+
+	return []definitionPart{{Path: eventstreamDefinitionPath, Payload: payload}}, nil
+}
@@ -0,0 +1,547 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// definitionPart is a single file of a Fabric item definition.
+type definitionPart struct {
+	Path    string
+	Payload []byte
+}
+
+// readDefinitionFile reads a local file and returns it as a definition part stored at partPath.
+func readDefinitionFile(localPath, partPath string) (definitionPart, error) {
+	payload, err := os.ReadFile(localPath)
+	if err != nil {
+		return definitionPart{}, fmt.Errorf("failed to read definition file %s: %w", localPath, err)
+	}
+
+	return definitionPart{Path: partPath, Payload: payload}, nil
+}
+
+// readDefinitionFolder reads every file below folder and returns them as definition parts.
+// The part paths are the slash-separated paths relative to folder, prefixed with prefix.
+func readDefinitionFolder(folder, prefix string) ([]definitionPart, error) {
+	var parts []definitionPart
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Local Power BI Desktop settings and caches are never part of an item definition.
+			if d.Name() == ".pbi" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+
+		part, err := readDefinitionFile(path, prefix+filepath.ToSlash(relativePath))
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definition folder %s: %w", folder, err)
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("definition folder %s contains no files", folder)
+	}
+
+	return parts, nil
+}
+
+// hashDefinitionParts returns a stable SHA-256 hash over the paths and payloads of the given parts.
+func hashDefinitionParts(parts []definitionPart) string {
+	sorted := make([]definitionPart, len(parts))
+	copy(sorted, parts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	hash := sha256.New()
+	for _, part := range sorted {
+		hash.Write([]byte(part.Path))
+		hash.Write([]byte{0})
+		hash.Write(part.Payload)
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// definitionBody converts definition parts into the request format of the Fabric items definition API.
+func definitionBody(format string, parts []definitionPart) map[string]interface{} {
+	requestParts := make([]map[string]interface{}, 0, len(parts))
+	for _, part := range parts {
+		requestParts = append(requestParts, map[string]interface{}{
+			"path":        part.Path,
+			"payload":     base64.StdEncoding.EncodeToString(part.Payload),
+			"payloadType": "InlineBase64",
+		})
+	}
+
+	definition := map[string]interface{}{
+		"parts": requestParts,
+	}
+	if format != "" {
+		definition["format"] = format
+	}
+
+	return definition
+}
+
+// getItemDefinition exports the current definition of an item, waiting for the operation if it runs long.
+func getItemDefinition(client *apiclient.APIClient, workspaceID, itemID, format string) ([]definitionPart, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/getDefinition", workspaceID, itemID)
+	if format != "" {
+		url += "?format=" + format
+	}
+
+	responseBody, err := client.PostWithLongRunningOperation(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, ok := responseBody["definition"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format: 'definition' key not found")
+	}
+
+	rawParts, _ := definition["parts"].([]interface{})
+	parts := make([]definitionPart, 0, len(rawParts))
+	for _, rawPart := range rawParts {
+		partMap, ok := rawPart.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		path, _ := partMap["path"].(string)
+		payload, _ := partMap["payload"].(string)
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode definition part %s: %w", path, err)
+		}
+		parts = append(parts, definitionPart{Path: path, Payload: decoded})
+	}
+
+	return parts, nil
+}
+
+// updateItemDefinition replaces the definition of an item, waiting for the operation if it runs long.
+func updateItemDefinition(client *apiclient.APIClient, workspaceID, itemID, format string, parts []definitionPart) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/updateDefinition", workspaceID, itemID)
+	body := map[string]interface{}{
+		"definition": definitionBody(format, parts),
+	}
+
+	_, err := client.PostWithLongRunningOperation(url, body)
+	return err
+}
+
+// definitionHashes are the computed attributes of a resource whose definition is uploaded from local files.
+type definitionHashes struct {
+	DefinitionHash       types.String
+	RemoteDefinitionHash types.String
+	LastUpdated          types.String
+}
+
+// planDefinitionHashes sets the hash of the local definition parts in planned. prior is nil when the item is
+// created. A definition whose hash differs from prior is uploaded again on apply, so the remote hash and the
+// timestamp become unknown; the framework only does that itself when the configuration changed.
+func planDefinitionHashes(parts []definitionPart, planned definitionHashes, prior *definitionHashes) definitionHashes {
+	planned.DefinitionHash = types.StringValue(hashDefinitionParts(parts))
+	if prior == nil {
+		return planned
+	}
+
+	if planned.DefinitionHash.Equal(prior.DefinitionHash) {
+		planned.RemoteDefinitionHash = prior.RemoteDefinitionHash
+	} else {
+		planned.RemoteDefinitionHash = types.StringUnknown()
+		planned.LastUpdated = types.StringUnknown()
+	}
+	return planned
+}
+
+// remoteDefinitionHash exports the definition of an item in the given format and hashes it. When paths are
+// given, only those parts are hashed, e.g. to leave out parts that carry platform metadata.
+func remoteDefinitionHash(client *apiclient.APIClient, workspaceID, itemID, format string, paths ...string) (string, error) {
+	parts, err := getItemDefinition(client, workspaceID, itemID, format)
+	if err != nil {
+		return "", err
+	}
+
+	if len(paths) > 0 {
+		var selected []definitionPart
+		for _, part := range parts {
+			if containsString(paths, part.Path) {
+				selected = append(selected, part)
+			}
+		}
+		parts = selected
+	}
+
+	return hashDefinitionParts(parts), nil
+}
+
+// refreshDefinitionHashes compares the exported definition with the one recorded after the last apply.
+// When it was changed outside of Terraform, the local hash is cleared so that the next plan uploads it again.
+func refreshDefinitionHashes(client *apiclient.APIClient, workspaceID, itemID, format string, hashes *definitionHashes, paths ...string) error {
+	remoteHash, err := remoteDefinitionHash(client, workspaceID, itemID, format, paths...)
+	if err != nil {
+		return err
+	}
+
+	if remoteHash != hashes.RemoteDefinitionHash.ValueString() {
+		hashes.DefinitionHash = types.StringNull()
+		hashes.RemoteDefinitionHash = types.StringValue(remoteHash)
+	}
+	return nil
+}
+
+// applyDefinition uploads the definition parts when their hash differs from prior and records the definition
+// as stored by Fabric, exported in the given format. It returns the hashes to store in the state.
+func applyDefinition(client *apiclient.APIClient, workspaceID, itemID, format string, parts []definitionPart, prior definitionHashes, paths ...string) (definitionHashes, error) {
+	hashes := definitionHashes{
+		DefinitionHash:       types.StringValue(hashDefinitionParts(parts)),
+		RemoteDefinitionHash: prior.RemoteDefinitionHash,
+		LastUpdated:          types.StringValue(time.Now().Format(time.RFC850)),
+	}
+	if hashes.DefinitionHash.Equal(prior.DefinitionHash) {
+		return hashes, nil
+	}
+
+	if err := updateItemDefinition(client, workspaceID, itemID, "", parts); err != nil {
+		return prior, fmt.Errorf("failed to update definition: %w", err)
+	}
+
+	remoteHash, err := remoteDefinitionHash(client, workspaceID, itemID, format, paths...)
+	if err != nil {
+		return prior, fmt.Errorf("failed to export definition: %w", err)
+	}
+	hashes.RemoteDefinitionHash = types.StringValue(remoteHash)
+
+	return hashes, nil
+}
+
+// definitionItem holds the attributes shared by the resources of items that are created from local definition files.
+type definitionItem struct {
+	definitionHashes
+	ID          types.String
+	WorkspaceID types.String
+	DisplayName types.String
+	Description types.String
+}
+
+// definitionItemModel is implemented by the resource models of items that are created from local definition files.
+type definitionItemModel interface {
+	definitionItem() definitionItem
+	setDefinitionItem(item definitionItem)
+	// definitionKnown reports whether every attribute that the definition is built from is known.
+	definitionKnown() bool
+	definitionParts() ([]definitionPart, error)
+	// definitionFormat returns the export format matching the configured definition source.
+	definitionFormat() string
+}
+
+// definitionItemKind describes a Fabric item type whose resource is managed by definitionItemResource.
+type definitionItemKind struct {
+	// name is the item type as used in diagnostics, e.g. "semantic model".
+	name string
+	// collection is the path segment of the item type in the Fabric API, e.g. "semanticModels".
+	collection string
+	newModel   func() definitionItemModel
+}
+
+// definitionItemResource implements the CRUD operations and the plan modification of an item that is created
+// from local definition files. Resources embed it and add their schema, metadata and configuration validation.
+type definitionItemResource struct {
+	client *apiclient.APIClient
+	kind   definitionItemKind
+}
+
+// ModifyPlan hashes the local definition files so that content changes show up in the plan.
+func (r *definitionItemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := r.kind.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.definitionKnown() {
+		return
+	}
+
+	parts, err := plan.definitionParts()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading "+r.kind.name+" definition",
+			"Could not read local "+r.kind.name+" definition: "+err.Error(),
+		)
+		return
+	}
+
+	var prior *definitionHashes
+	if !req.State.Raw.IsNull() {
+		state := r.kind.newModel()
+		diags = req.State.Get(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		stateHashes := state.definitionItem().definitionHashes
+		prior = &stateHashes
+	}
+	item := plan.definitionItem()
+	item.definitionHashes = planDefinitionHashes(parts, item.definitionHashes, prior)
+	plan.setDefinitionItem(item)
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create creates the item together with its definition.
+func (r *definitionItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := r.kind.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parts, err := plan.definitionParts()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading "+r.kind.name+" definition",
+			"Could not read local "+r.kind.name+" definition: "+err.Error(),
+		)
+		return
+	}
+
+	item := plan.definitionItem()
+	itemID, err := r.createItem(item.WorkspaceID.ValueString(), item.DisplayName.ValueString(), item.Description.ValueString(), parts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating "+r.kind.name,
+			"Could not create "+r.kind.name+": "+err.Error(),
+		)
+		return
+	}
+
+	item.ID = types.StringValue(itemID)
+	item.DefinitionHash = types.StringValue(hashDefinitionParts(parts))
+	item.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Export the definition as stored by Fabric to detect later changes made outside of Terraform.
+	remoteHash, err := remoteDefinitionHash(r.client, item.WorkspaceID.ValueString(), itemID, plan.definitionFormat())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting "+r.kind.name+" definition",
+			"Could not export "+r.kind.name+" definition: "+err.Error(),
+		)
+		return
+	}
+	item.RemoteDefinitionHash = types.StringValue(remoteHash)
+	plan.setDefinitionItem(item)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the display name and description and detects changes of the definition made outside of Terraform.
+func (r *definitionItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.kind.newModel()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := state.definitionItem()
+	remote, err := r.client.Get(r.itemURL(item.WorkspaceID.ValueString(), item.ID.ValueString()))
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading "+r.kind.name,
+			"Could not read "+r.kind.name+": "+err.Error(),
+		)
+		return
+	}
+
+	displayName, ok := remote["displayName"].(string)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error reading "+r.kind.name,
+			"Unexpected response format: 'displayName' key not found or not a string",
+		)
+		return
+	}
+	description, _ := remote["description"].(string) // description is optional
+
+	item.DisplayName = types.StringValue(displayName)
+	if !item.Description.IsNull() || description != "" {
+		item.Description = types.StringValue(description)
+	}
+
+	// Compare the exported definition with the one recorded after the last apply.
+	err = refreshDefinitionHashes(r.client, item.WorkspaceID.ValueString(), item.ID.ValueString(), state.definitionFormat(), &item.definitionHashes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting "+r.kind.name+" definition",
+			"Could not export "+r.kind.name+" definition: "+err.Error(),
+		)
+		return
+	}
+	state.setDefinitionItem(item)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update renames the item and uploads its definition when it changed locally or in Fabric.
+func (r *definitionItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.kind.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := r.kind.newModel()
+	diags = req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := plan.definitionItem()
+	prior := state.definitionItem()
+	item.ID = prior.ID // Ensure the ID remains unchanged.
+
+	if !item.DisplayName.Equal(prior.DisplayName) || !item.Description.Equal(prior.Description) {
+		err := r.updateItem(prior.WorkspaceID.ValueString(), prior.ID.ValueString(), item.DisplayName.ValueString(), item.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating "+r.kind.name,
+				"Could not update "+r.kind.name+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	parts, err := plan.definitionParts()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading "+r.kind.name+" definition",
+			"Could not read local "+r.kind.name+" definition: "+err.Error(),
+		)
+		return
+	}
+
+	// Upload the definition if it changed and record the definition as stored by Fabric.
+	hashes, err := applyDefinition(r.client, prior.WorkspaceID.ValueString(), prior.ID.ValueString(), plan.definitionFormat(), parts, prior.definitionHashes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating "+r.kind.name+" definition",
+			"Could not update "+r.kind.name+" definition: "+err.Error(),
+		)
+		return
+	}
+	item.definitionHashes = hashes
+	plan.setDefinitionItem(item)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the item.
+func (r *definitionItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := r.kind.newModel()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := state.definitionItem()
+	err := r.client.Delete(r.itemURL(item.WorkspaceID.ValueString(), item.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting "+r.kind.name,
+			"Could not delete "+r.kind.name+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// itemURL returns the URL of an item in the Fabric API of its item type.
+func (r *definitionItemResource) itemURL(workspaceID, itemID string) string {
+	return fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/%s/%s", workspaceID, r.kind.collection, itemID)
+}
+
+// createItem creates an item with its definition and waits for the operation to finish.
+func (r *definitionItemResource) createItem(workspaceID, displayName, description string, parts []definitionPart) (string, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/%s", workspaceID, r.kind.collection)
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+		"definition":  definitionBody("", parts),
+	}
+
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to make POST request: %w", err)
+	}
+
+	itemID, ok := responseBody["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected response format: 'id' key not found")
+	}
+
+	return itemID, nil
+}
+
+// updateItem updates the display name and description of an item.
+func (r *definitionItemResource) updateItem(workspaceID, itemID, displayName, description string) error {
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+	}
+
+	_, err := r.client.Patch(r.itemURL(workspaceID, itemID), body)
+	return err
+}
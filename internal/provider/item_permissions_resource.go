@@ -0,0 +1,537 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &itemPermissionsResource{}
+)
+
+const (
+	// itemPermissionsModeAdditive manages only the declared principals and leaves other grants alone.
+	itemPermissionsModeAdditive = "additive"
+	// itemPermissionsModeAuthoritative also revokes the direct grants of principals that are not declared.
+	itemPermissionsModeAuthoritative = "authoritative"
+)
+
+// validItemPermissions lists the item permissions that can be granted. They map onto the dataset user
+// access rights of the Power BI API: Read, ReadReshare, ReadExplore and ReadReshareExplore.
+var validItemPermissions = []string{"Read", "Reshare", "Build"}
+
+// validItemPermissionPrincipalTypes lists the principal types that can be granted item permissions.
+var validItemPermissionPrincipalTypes = []string{"User", "Group", "App"}
+
+// itemPermissionsResource grants principals permissions on a semantic model without giving them a workspace
+// role. It uses the Power BI dataset users API, the only documented API for item level sharing.
+type itemPermissionsResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the item permissions resource.
+func (r *itemPermissionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Grants principals permissions on a semantic model without a workspace role, through the Power BI dataset users API. Lakehouses, warehouses and reports have no documented item sharing API and are not supported. Only direct grants are managed; access through workspace roles is not affected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the permissions in the form '<workspace id>/<item id>'.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the item.",
+				PlanModifiers: requiresReplace,
+			},
+			"item_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the semantic model.",
+				PlanModifiers: requiresReplace,
+			},
+			"mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(itemPermissionsModeAdditive),
+				Description: "'additive' manages only the declared principals. 'authoritative' also revokes the direct grants of every other principal, which then show up as drift. Defaults to 'additive'.",
+			},
+			"grants": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "The permissions of each principal on the item.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal_id": schema.StringAttribute{
+							Required:    true,
+							Description: "The user principal name of a user, or the Microsoft Entra object ID of a group or service principal.",
+						},
+						"principal_type": schema.StringAttribute{
+							Required:    true,
+							Description: fmt.Sprintf("The type of the principal: %s.", quotedList(validItemPermissionPrincipalTypes)),
+						},
+						"permissions": schema.SetAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Description: fmt.Sprintf("The permissions of the principal: %s. 'Read' is required; 'Build' is called Explore in the Power BI API.", quotedList(validItemPermissions)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// itemPermissionsResourceModel defines the model of the item permissions resource.
+type itemPermissionsResourceModel struct {
+	ID          types.String               `tfsdk:"id"`
+	WorkspaceID types.String               `tfsdk:"workspace_id"`
+	ItemID      types.String               `tfsdk:"item_id"`
+	Mode        types.String               `tfsdk:"mode"`
+	Grants      []itemPermissionGrantModel `tfsdk:"grants"`
+}
+
+// itemPermissionGrantModel defines the permissions of one principal.
+type itemPermissionGrantModel struct {
+	PrincipalID   types.String   `tfsdk:"principal_id"`
+	PrincipalType types.String   `tfsdk:"principal_type"`
+	Permissions   []types.String `tfsdk:"permissions"`
+}
+
+// Metadata sets the resource type name.
+func (r *itemPermissionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_item_permissions"
+}
+
+// NewItemPermissionsResource creates a new item permissions resource.
+func NewItemPermissionsResource(client *apiclient.APIClient) resource.Resource {
+	return &itemPermissionsResource{client: client}
+}
+
+// ValidateConfig checks the mode, the principal types and permissions, and that no principal is declared twice.
+func (r *itemPermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var mode types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("mode"), &mode)
+	resp.Diagnostics.Append(diags...)
+	if !mode.IsNull() && !mode.IsUnknown() && mode.ValueString() != itemPermissionsModeAdditive && mode.ValueString() != itemPermissionsModeAuthoritative {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mode"),
+			"Invalid mode",
+			fmt.Sprintf("Unsupported mode %q. Available options are 'additive' and 'authoritative'.", mode.ValueString()),
+		)
+	}
+
+	var grants types.Set
+	diags = req.Config.GetAttribute(ctx, path.Root("grants"), &grants)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || grants.IsNull() || grants.IsUnknown() {
+		return
+	}
+
+	principals := make(map[string]bool)
+	for _, element := range grants.Elements() {
+		grant, ok := element.(types.Object)
+		if !ok || grant.IsNull() || grant.IsUnknown() {
+			continue
+		}
+		attributes := grant.Attributes()
+
+		if principalID, ok := attributes["principal_id"].(types.String); ok && !principalID.IsUnknown() {
+			key := strings.ToLower(principalID.ValueString())
+			if principals[key] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("grants"),
+					"Duplicate principal",
+					fmt.Sprintf("Principal %s is declared more than once. Combine its permissions into one grant.", principalID.ValueString()),
+				)
+			}
+			principals[key] = true
+		}
+
+		if principalType, ok := attributes["principal_type"].(types.String); ok && !principalType.IsUnknown() && !containsString(validItemPermissionPrincipalTypes, principalType.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grants"),
+				"Invalid principal type",
+				fmt.Sprintf("Unsupported principal type %q. Available options are %s.", principalType.ValueString(), quotedList(validItemPermissionPrincipalTypes)),
+			)
+		}
+
+		permissions, ok := attributes["permissions"].(types.Set)
+		if !ok || permissions.IsUnknown() {
+			continue
+		}
+		hasRead, allKnown := false, true
+		for _, permissionElement := range permissions.Elements() {
+			permission, ok := permissionElement.(types.String)
+			if !ok || permission.IsUnknown() {
+				allKnown = false
+				continue
+			}
+			if permission.ValueString() == "Read" {
+				hasRead = true
+			}
+			if !containsString(validItemPermissions, permission.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("grants"),
+					"Invalid permission",
+					fmt.Sprintf("Unsupported permission %q. Available options are %s.", permission.ValueString(), quotedList(validItemPermissions)),
+				)
+			}
+		}
+		if allKnown && !hasRead {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grants"),
+				"Missing permission",
+				"Every grant needs the 'Read' permission; 'Reshare' and 'Build' extend it.",
+			)
+		}
+	}
+}
+
+// Create grants the declared permissions, and in authoritative mode revokes all other direct grants.
+func (r *itemPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan itemPermissionsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.WorkspaceID.ValueString() + "/" + plan.ItemID.ValueString())
+
+	diags = r.applyGrants(&plan, nil)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the grants. In additive mode only the declared principals are read back; in authoritative
+// mode every direct grant of the item is, so that grants made outside of Terraform show up in the plan.
+func (r *itemPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state itemPermissionsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.listGrants(state.WorkspaceID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading item permissions",
+			fmt.Sprintf("Could not list the permissions of item %s: %s", state.ItemID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Grants = r.grantsInScope(state, existing)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update grants changed permissions and revokes the grants that are no longer declared.
+func (r *itemPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan itemPermissionsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state itemPermissionsResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	diags = r.applyGrants(&plan, state.Grants)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete revokes the grants of all principals in the state.
+func (r *itemPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state itemPermissionsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var remaining []itemPermissionGrantModel
+	for _, grant := range state.Grants {
+		err := r.revokeGrant(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), grant)
+		if err != nil && !isNotFoundError(err) {
+			remaining = append(remaining, grant)
+			resp.Diagnostics.AddError(
+				"Error revoking item permissions",
+				fmt.Sprintf("Could not revoke the permissions of %s: %v", grant.PrincipalID.ValueString(), err),
+			)
+		}
+	}
+
+	if len(remaining) > 0 {
+		// Keep the grants that could not be revoked so that the next destroy retries them.
+		state.Grants = remaining
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// applyGrants makes the direct grants of the item match the plan. Principals whose permissions differ are
+// granted their declared permissions, then the principals of prior that are no longer declared are revoked,
+// and in authoritative mode every other principal with a direct grant as well. When a change fails, the
+// grants of the plan are replaced by the actual grants so that the next apply retries the change.
+func (r *itemPermissionsResource) applyGrants(plan *itemPermissionsResourceModel, prior []itemPermissionGrantModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	workspaceID := plan.WorkspaceID.ValueString()
+	itemID := plan.ItemID.ValueString()
+
+	existing, err := r.listGrants(workspaceID, itemID)
+	if err != nil {
+		diags.AddError(
+			"Error listing item permissions",
+			fmt.Sprintf("Could not list the permissions of item %s: %s", itemID, err.Error()),
+		)
+		plan.Grants = prior
+		return diags
+	}
+
+	declared := make(map[string]bool, len(plan.Grants))
+	for _, grant := range plan.Grants {
+		principalID := strings.ToLower(grant.PrincipalID.ValueString())
+		declared[principalID] = true
+
+		current, exists := existing[principalID]
+		if exists && equalPermissions(current.Permissions, grant.Permissions) {
+			continue
+		}
+		if err := r.grant(workspaceID, itemID, grant, exists); err != nil {
+			diags.AddError(
+				"Error granting item permissions",
+				fmt.Sprintf("Could not grant %s to %s: %v", strings.Join(sortedPermissions(grant.Permissions), ", "), grant.PrincipalID.ValueString(), err),
+			)
+		}
+	}
+
+	revoke := make(map[string]bool)
+	for _, grant := range prior {
+		revoke[strings.ToLower(grant.PrincipalID.ValueString())] = true
+	}
+	if plan.Mode.ValueString() == itemPermissionsModeAuthoritative {
+		for principalID := range existing {
+			revoke[principalID] = true
+		}
+	}
+	for principalID := range revoke {
+		if declared[principalID] {
+			continue
+		}
+		if _, ok := existing[principalID]; !ok {
+			continue
+		}
+		if err := r.revokeGrant(workspaceID, itemID, existing[principalID]); err != nil && !isNotFoundError(err) {
+			diags.AddError(
+				"Error revoking item permissions",
+				fmt.Sprintf("Could not revoke the permissions of %s: %v", existing[principalID].PrincipalID.ValueString(), err),
+			)
+		}
+	}
+
+	if diags.HasError() {
+		if actual, err := r.listGrants(workspaceID, itemID); err == nil {
+			plan.Grants = r.grantsInScope(*plan, actual)
+		} else {
+			plan.Grants = prior
+		}
+	}
+
+	return diags
+}
+
+// grantsInScope returns the grants the resource is responsible for: every direct grant in authoritative mode,
+// otherwise the grants of the principals declared in model.
+func (r *itemPermissionsResource) grantsInScope(model itemPermissionsResourceModel, existing map[string]itemPermissionGrantModel) []itemPermissionGrantModel {
+	var grants []itemPermissionGrantModel
+
+	if model.Mode.ValueString() == itemPermissionsModeAuthoritative {
+		configured := make(map[string]types.String, len(model.Grants))
+		for _, grant := range model.Grants {
+			configured[strings.ToLower(grant.PrincipalID.ValueString())] = grant.PrincipalID
+		}
+
+		principalIDs := make([]string, 0, len(existing))
+		for principalID := range existing {
+			principalIDs = append(principalIDs, principalID)
+		}
+		sort.Strings(principalIDs)
+		for _, principalID := range principalIDs {
+			current := existing[principalID]
+			// Keep the configured spelling of the principal ID.
+			if spelling, ok := configured[principalID]; ok {
+				current.PrincipalID = spelling
+			}
+			grants = append(grants, current)
+		}
+		return grants
+	}
+
+	for _, grant := range model.Grants {
+		current, ok := existing[strings.ToLower(grant.PrincipalID.ValueString())]
+		if !ok {
+			continue
+		}
+		// Keep the configured spelling of the principal ID.
+		current.PrincipalID = grant.PrincipalID
+		grants = append(grants, current)
+	}
+	return grants
+}
+
+// listGrants returns the direct grants of the semantic model keyed by lower case principal ID. Principals
+// with write access get it from a workspace role and are skipped.
+func (r *itemPermissionsResource) listGrants(workspaceID, itemID string) (map[string]itemPermissionGrantModel, error) {
+	grants := make(map[string]itemPermissionGrantModel)
+
+	resp, err := r.client.Get(itemPermissionsURL(workspaceID, itemID))
+	if err != nil {
+		return nil, err
+	}
+	if errorCode, ok := getMapString("errorCode", resp); ok {
+		message, _ := getMapString("message", resp)
+		return nil, fmt.Errorf("%s: %s", errorCode, message)
+	}
+
+	values, _ := resp["value"].([]interface{})
+	for _, value := range values {
+		entry, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		principalID, ok := getMapString("identifier", entry)
+		if !ok {
+			continue
+		}
+		accessRight, _ := getMapString("datasetUserAccessRight", entry)
+		if accessRight == "" || accessRight == "None" || strings.Contains(accessRight, "Write") {
+			continue
+		}
+		principalType, _ := getMapString("principalType", entry)
+
+		grants[strings.ToLower(principalID)] = itemPermissionGrantModel{
+			PrincipalID:   types.StringValue(principalID),
+			PrincipalType: types.StringValue(principalType),
+			Permissions:   itemPermissionsFromAccessRight(accessRight),
+		}
+	}
+
+	return grants, nil
+}
+
+// grant sets the permissions of one principal on the semantic model. A principal without access is added;
+// the access right of a principal that already has access is replaced.
+func (r *itemPermissionsResource) grant(workspaceID, itemID string, grant itemPermissionGrantModel, exists bool) error {
+	body := map[string]interface{}{
+		"identifier":             grant.PrincipalID.ValueString(),
+		"principalType":          grant.PrincipalType.ValueString(),
+		"datasetUserAccessRight": itemPermissionsAccessRight(grant.Permissions),
+	}
+
+	if exists {
+		_, err := r.client.Put(itemPermissionsURL(workspaceID, itemID), body)
+		return err
+	}
+	_, err := r.client.Post(itemPermissionsURL(workspaceID, itemID), body)
+	return err
+}
+
+// revokeGrant removes all direct permissions of one principal by setting its access right to None.
+func (r *itemPermissionsResource) revokeGrant(workspaceID, itemID string, grant itemPermissionGrantModel) error {
+	body := map[string]interface{}{
+		"identifier":             grant.PrincipalID.ValueString(),
+		"principalType":          grant.PrincipalType.ValueString(),
+		"datasetUserAccessRight": "None",
+	}
+
+	_, err := r.client.Put(itemPermissionsURL(workspaceID, itemID), body)
+	return err
+}
+
+// itemPermissionsURL returns the URL of the dataset users of a semantic model.
+func itemPermissionsURL(workspaceID, itemID string) string {
+	return fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/users", workspaceID, itemID)
+}
+
+// itemPermissionsAccessRight returns the dataset user access right that grants the permissions.
+func itemPermissionsAccessRight(permissions []types.String) string {
+	values := stringValues(permissions)
+	accessRight := "Read"
+	if containsString(values, "Reshare") {
+		accessRight += "Reshare"
+	}
+	if containsString(values, "Build") {
+		accessRight += "Explore"
+	}
+	return accessRight
+}
+
+// itemPermissionsFromAccessRight returns the permissions contained in a dataset user access right.
+func itemPermissionsFromAccessRight(accessRight string) []types.String {
+	permissions := []types.String{types.StringValue("Read")}
+	if strings.Contains(accessRight, "Reshare") {
+		permissions = append(permissions, types.StringValue("Reshare"))
+	}
+	if strings.Contains(accessRight, "Explore") {
+		permissions = append(permissions, types.StringValue("Build"))
+	}
+	return permissions
+}
+
+// sortedPermissions returns the permissions as sorted strings.
+func sortedPermissions(permissions []types.String) []string {
+	values := stringValues(permissions)
+	sort.Strings(values)
+	return values
+}
+
+// equalPermissions reports whether two permission sets contain the same permissions.
+func equalPermissions(a, b []types.String) bool {
+	sortedA := sortedPermissions(a)
+	sortedB := sortedPermissions(b)
+	if len(sortedA) != len(sortedB) {
+		return false
+	}
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
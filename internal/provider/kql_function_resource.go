@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// kqlFunctionResource manages a stored function of a KQL database.
+type kqlFunctionResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the KQL function resource.
+func (r *kqlFunctionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The identifier of the function in the form '<kql database id>/<function name>'.",
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the function. Changing the name recreates the function.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"parameters": schema.StringAttribute{
+			Optional:    true,
+			Description: "The parameter list of the function without parentheses, for example 'from:datetime, to:datetime'.",
+		},
+		"body": schema.StringAttribute{
+			Required:    true,
+			Description: "The body of the function without the surrounding braces.",
+		},
+		"folder": schema.StringAttribute{
+			Optional:    true,
+			Description: "The folder the function is shown in.",
+		},
+		"docstring": schema.StringAttribute{
+			Optional:    true,
+			Description: "A description of the function.",
+		},
+		"skip_validation": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Skip the semantic validation of the body, e.g. when it references tables that are created later.",
+		},
+		"last_updated": schema.StringAttribute{
+			Computed:    true,
+			Description: "The timestamp of the last update.",
+		},
+	}
+	for name, attribute := range kqlDatabaseSchemaAttributes() {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// kqlFunctionResourceModel defines the model of the KQL function resource.
+type kqlFunctionResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	WorkspaceID    types.String `tfsdk:"workspace_id"`
+	KqlDatabaseID  types.String `tfsdk:"kql_database_id"`
+	Name           types.String `tfsdk:"name"`
+	Parameters     types.String `tfsdk:"parameters"`
+	Body           types.String `tfsdk:"body"`
+	Folder         types.String `tfsdk:"folder"`
+	Docstring      types.String `tfsdk:"docstring"`
+	SkipValidation types.Bool   `tfsdk:"skip_validation"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+}
+
+// Metadata sets the resource type name.
+func (r *kqlFunctionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_kql_function"
+}
+
+// NewKqlFunctionResource creates a new KQL function resource.
+func NewKqlFunctionResource(client *apiclient.APIClient) resource.Resource {
+	return &kqlFunctionResource{client: client}
+}
+
+// Create creates the function with .create-or-alter function.
+func (r *kqlFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan kqlFunctionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.createOrAlterFunction(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating KQL function",
+			"Could not create KQL function: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.KqlDatabaseID.ValueString() + "/" + plan.Name.ValueString())
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the function with .show functions.
+func (r *kqlFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state kqlFunctionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	rows, err := executeKqlCommand(r.client, endpoint, fmt.Sprintf(".show functions | where Name == %s", kustoString(state.Name.ValueString())))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL function",
+			"Could not read KQL function: "+err.Error(),
+		)
+		return
+	}
+	if len(rows) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Kusto returns the parameters in parentheses and the body in braces.
+	parameters := strings.TrimSuffix(strings.TrimPrefix(kustoRowString(rows[0], "Parameters"), "("), ")")
+	body := strings.TrimSpace(kustoRowString(rows[0], "Body"))
+	body = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(body, "{"), "}"))
+
+	if normalizeKqlParameters(parameters) != normalizeKqlParameters(state.Parameters.ValueString()) {
+		state.Parameters = types.StringValue(parameters)
+	}
+	if body != strings.TrimSpace(state.Body.ValueString()) {
+		state.Body = types.StringValue(body)
+	}
+
+	folder := kustoRowString(rows[0], "Folder")
+	if !state.Folder.IsNull() || folder != "" {
+		state.Folder = types.StringValue(folder)
+	}
+	docstring := kustoRowString(rows[0], "DocString")
+	if !state.Docstring.IsNull() || docstring != "" {
+		state.Docstring = types.StringValue(docstring)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update replaces the function with .create-or-alter function.
+func (r *kqlFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan kqlFunctionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state kqlFunctionResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.createOrAlterFunction(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating KQL function",
+			"Could not update KQL function: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete drops the function.
+func (r *kqlFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state kqlFunctionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		// Functions are removed together with their database.
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	if _, err := executeKqlCommand(r.client, endpoint, fmt.Sprintf(".drop function %s ifexists", kustoName(state.Name.ValueString()))); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting KQL function",
+			"Could not drop KQL function: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// createOrAlterFunction sends the .create-or-alter function command for the planned function.
+func (r *kqlFunctionResource) createOrAlterFunction(model kqlFunctionResourceModel) error {
+	endpoint, err := getKqlDatabaseEndpoint(r.client, model.WorkspaceID.ValueString(), model.KqlDatabaseID.ValueString())
+	if err != nil {
+		return fmt.Errorf("could not read the query service URI of the KQL database: %w", err)
+	}
+
+	properties := []string{
+		"folder = " + kustoString(model.Folder.ValueString()),
+		"docstring = " + kustoString(model.Docstring.ValueString()),
+	}
+	if model.SkipValidation.ValueBool() {
+		properties = append(properties, `skipvalidation = "true"`)
+	}
+
+	command := fmt.Sprintf(".create-or-alter function with (%s) %s(%s) {\n%s\n}",
+		strings.Join(properties, ", "),
+		kustoName(model.Name.ValueString()),
+		model.Parameters.ValueString(),
+		strings.TrimSpace(model.Body.ValueString()),
+	)
+
+	_, err = executeKqlCommand(r.client, endpoint, command)
+	return err
+}
+
+// normalizeKqlParameters removes whitespace from a parameter list so that formatting does not show up as drift.
+func normalizeKqlParameters(parameters string) string {
+	return strings.Join(strings.Fields(parameters), "")
+}
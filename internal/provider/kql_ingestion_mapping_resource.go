@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// kqlIngestionMappingResource manages an ingestion mapping of a KQL table.
+type kqlIngestionMappingResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the KQL ingestion mapping resource.
+func (r *kqlIngestionMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The identifier of the mapping in the form '<kql database id>/<table name>/<kind>/<mapping name>'.",
+		},
+		"table_name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the table the mapping belongs to.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the mapping, referenced by ingestion requests.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"kind": schema.StringAttribute{
+			Required:    true,
+			Description: "The data format of the mapping: 'csv', 'json', 'avro', 'parquet', 'orc' or 'w3clogfile'.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"mapping": schema.StringAttribute{
+			Required:    true,
+			Description: "The mapping as a JSON array, for example jsonencode([{ column = \"Timestamp\", Properties = { Path = \"$.ts\" } }]).",
+		},
+		"remote_mapping": schema.StringAttribute{
+			Computed:    true,
+			Description: "The mapping as returned by .show after the last apply. Used to detect changes made outside of Terraform.",
+		},
+		"last_updated": schema.StringAttribute{
+			Computed:    true,
+			Description: "The timestamp of the last update.",
+		},
+	}
+	for name, attribute := range kqlDatabaseSchemaAttributes() {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// kqlIngestionMappingResourceModel defines the model of the KQL ingestion mapping resource.
+type kqlIngestionMappingResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	WorkspaceID   types.String `tfsdk:"workspace_id"`
+	KqlDatabaseID types.String `tfsdk:"kql_database_id"`
+	TableName     types.String `tfsdk:"table_name"`
+	Name          types.String `tfsdk:"name"`
+	Kind          types.String `tfsdk:"kind"`
+	Mapping       types.String `tfsdk:"mapping"`
+	RemoteMapping types.String `tfsdk:"remote_mapping"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+}
+
+// Metadata sets the resource type name.
+func (r *kqlIngestionMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_kql_ingestion_mapping"
+}
+
+// NewKqlIngestionMappingResource creates a new KQL ingestion mapping resource.
+func NewKqlIngestionMappingResource(client *apiclient.APIClient) resource.Resource {
+	return &kqlIngestionMappingResource{client: client}
+}
+
+// Create creates the mapping with .create-or-alter ingestion mapping.
+func (r *kqlIngestionMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan kqlIngestionMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteMapping, err := r.createOrAlterMapping(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating KQL ingestion mapping",
+			"Could not create KQL ingestion mapping: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strings.Join([]string{plan.KqlDatabaseID.ValueString(), plan.TableName.ValueString(), plan.Kind.ValueString(), plan.Name.ValueString()}, "/"))
+	plan.RemoteMapping = types.StringValue(remoteMapping)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read compares the mapping returned by .show ingestion mappings with the one recorded after the last apply.
+func (r *kqlIngestionMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state kqlIngestionMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	remoteMapping, err := r.showMapping(endpoint, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL ingestion mapping",
+			"Could not read KQL ingestion mapping: "+err.Error(),
+		)
+		return
+	}
+	if remoteMapping == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if remoteMapping != state.RemoteMapping.ValueString() {
+		// Show the mapping as it is in the database, so that the plan restores the configured one.
+		state.Mapping = types.StringValue(remoteMapping)
+		state.RemoteMapping = types.StringValue(remoteMapping)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update replaces the mapping with .create-or-alter ingestion mapping.
+func (r *kqlIngestionMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan kqlIngestionMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state kqlIngestionMappingResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteMapping, err := r.createOrAlterMapping(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating KQL ingestion mapping",
+			"Could not update KQL ingestion mapping: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.RemoteMapping = types.StringValue(remoteMapping)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete drops the mapping.
+func (r *kqlIngestionMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state kqlIngestionMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	command := fmt.Sprintf(".drop table %s ingestion %s mapping %s", kustoName(state.TableName.ValueString()), state.Kind.ValueString(), kustoString(state.Name.ValueString()))
+	if _, err := executeKqlCommand(r.client, endpoint, command); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting KQL ingestion mapping",
+			"Could not drop KQL ingestion mapping: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// createOrAlterMapping sets the planned mapping and returns the mapping as stored by Kusto.
+func (r *kqlIngestionMappingResource) createOrAlterMapping(model kqlIngestionMappingResourceModel) (string, error) {
+	endpoint, err := getKqlDatabaseEndpoint(r.client, model.WorkspaceID.ValueString(), model.KqlDatabaseID.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("could not read the query service URI of the KQL database: %w", err)
+	}
+
+	command := fmt.Sprintf(".create-or-alter table %s ingestion %s mapping %s %s",
+		kustoName(model.TableName.ValueString()),
+		model.Kind.ValueString(),
+		kustoString(model.Name.ValueString()),
+		kustoMultilineString(model.Mapping.ValueString()),
+	)
+	if _, err := executeKqlCommand(r.client, endpoint, command); err != nil {
+		return "", err
+	}
+
+	return r.showMapping(endpoint, model)
+}
+
+// showMapping returns the compact mapping JSON, or an empty string if the mapping does not exist.
+func (r *kqlIngestionMappingResource) showMapping(endpoint kqlDatabaseEndpoint, model kqlIngestionMappingResourceModel) (string, error) {
+	command := fmt.Sprintf(".show table %s ingestion %s mappings | where Name == %s",
+		kustoName(model.TableName.ValueString()),
+		model.Kind.ValueString(),
+		kustoString(model.Name.ValueString()),
+	)
+	rows, err := executeKqlCommand(r.client, endpoint, command)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	return compactJSON(kustoRowString(rows[0], "Mapping")), nil
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// kqlDatabaseEndpoint is the management endpoint of a KQL database.
+type kqlDatabaseEndpoint struct {
+	QueryServiceURI string
+	DatabaseName    string
+}
+
+// kqlDatabaseSchemaAttributes returns the attributes that locate the KQL database of a schema object.
+func kqlDatabaseSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"workspace_id": schema.StringAttribute{
+			Required:    true,
+			Description: "The ID of the workspace of the KQL database.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"kql_database_id": schema.StringAttribute{
+			Required:    true,
+			Description: "The ID of the KQL database. Management commands are sent to its query service URI.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+// getKqlDatabaseEndpoint reads the query service URI and the database name from the KQL database properties.
+func getKqlDatabaseEndpoint(client *apiclient.APIClient, workspaceID, kqlDatabaseID string) (kqlDatabaseEndpoint, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/kqlDatabases/%s", workspaceID, kqlDatabaseID)
+	kqlDatabase, err := client.Get(url)
+	if err != nil {
+		return kqlDatabaseEndpoint{}, err
+	}
+
+	databaseName, ok := kqlDatabase["displayName"].(string)
+	if !ok {
+		return kqlDatabaseEndpoint{}, fmt.Errorf("unexpected response format: 'displayName' key not found")
+	}
+
+	properties, _ := kqlDatabase["properties"].(map[string]interface{})
+	queryServiceURI, _ := getMapString("queryServiceUri", properties)
+	if queryServiceURI == "" {
+		return kqlDatabaseEndpoint{}, fmt.Errorf("KQL database %s has no query service URI yet", kqlDatabaseID)
+	}
+
+	return kqlDatabaseEndpoint{QueryServiceURI: queryServiceURI, DatabaseName: databaseName}, nil
+}
+
+// executeKqlCommand runs a management command against the KQL database.
+func executeKqlCommand(client *apiclient.APIClient, endpoint kqlDatabaseEndpoint, command string) ([]apiclient.KustoResultRow, error) {
+	return client.ExecuteKustoManagementCommand(endpoint.QueryServiceURI, endpoint.DatabaseName, command)
+}
+
+// kustoName quotes an entity name, so names with spaces or dashes can be used in commands.
+func kustoName(name string) string {
+	return "['" + strings.ReplaceAll(strings.ReplaceAll(name, `\`, `\\`), "'", `\'`) + "']"
+}
+
+// kustoString quotes a string literal.
+func kustoString(value string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// kustoMultilineString wraps a JSON document in a multi-line string literal.
+func kustoMultilineString(value string) string {
+	return "```" + value + "```"
+}
+
+// kustoRowString returns a string column of a management command result row.
+func kustoRowString(row apiclient.KustoResultRow, column string) string {
+	value, _ := row[column].(string)
+	return value
+}
+
+// compactJSON normalises a JSON document so that formatting differences do not show up as drift.
+func compactJSON(value string) string {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, []byte(value)); err != nil {
+		return strings.TrimSpace(value)
+	}
+	return buffer.String()
+}
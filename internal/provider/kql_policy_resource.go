@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &kqlPolicyResource{}
+)
+
+// kqlPolicyResource manages a policy of a KQL database, table or materialized view.
+type kqlPolicyResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the KQL policy resource.
+func (r *kqlPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The identifier of the policy in the form '<kql database id>/<entity type>/<entity name>/<policy type>'.",
+		},
+		"entity_type": schema.StringAttribute{
+			Required:    true,
+			Description: "The kind of entity the policy applies to: 'database', 'table' or 'materialized-view'.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"entity_name": schema.StringAttribute{
+			Optional:    true,
+			Description: "The name of the table or materialized view. Not used for database policies.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"policy_type": schema.StringAttribute{
+			Required:    true,
+			Description: "The policy kind, for example 'retention', 'caching', 'update', 'ingestionbatching', 'partitioning' or 'streamingingestion'.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"policy": schema.StringAttribute{
+			Required:    true,
+			Description: "The policy as a JSON document, for example jsonencode({ SoftDeletePeriod = \"30.00:00:00\", Recoverability = \"Enabled\" }). For caching policies, the hot cache period as a timespan such as '7d'.",
+		},
+		"remote_policy": schema.StringAttribute{
+			Computed:    true,
+			Description: "The policy as returned by .show after the last apply. Used to detect changes made outside of Terraform.",
+		},
+		"last_updated": schema.StringAttribute{
+			Computed:    true,
+			Description: "The timestamp of the last update.",
+		},
+	}
+	for name, attribute := range kqlDatabaseSchemaAttributes() {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// kqlPolicyResourceModel defines the model of the KQL policy resource.
+type kqlPolicyResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	WorkspaceID   types.String `tfsdk:"workspace_id"`
+	KqlDatabaseID types.String `tfsdk:"kql_database_id"`
+	EntityType    types.String `tfsdk:"entity_type"`
+	EntityName    types.String `tfsdk:"entity_name"`
+	PolicyType    types.String `tfsdk:"policy_type"`
+	Policy        types.String `tfsdk:"policy"`
+	RemotePolicy  types.String `tfsdk:"remote_policy"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+}
+
+// Metadata sets the resource type name.
+func (r *kqlPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_kql_policy"
+}
+
+// NewKqlPolicyResource creates a new KQL policy resource.
+func NewKqlPolicyResource(client *apiclient.APIClient) resource.Resource {
+	return &kqlPolicyResource{client: client}
+}
+
+// ValidateConfig checks the entity the policy applies to.
+func (r *kqlPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config kqlPolicyResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.EntityType.IsUnknown() || config.EntityName.IsUnknown() {
+		return
+	}
+
+	switch config.EntityType.ValueString() {
+	case "database":
+	case "table", "materialized-view":
+		if config.EntityName.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("entity_name"),
+				"Missing entity name",
+				fmt.Sprintf("entity_name is required for %s policies.", config.EntityType.ValueString()),
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("entity_type"),
+			"Invalid entity type",
+			"entity_type must be 'database', 'table' or 'materialized-view'.",
+		)
+	}
+}
+
+// Create sets the policy with .alter policy.
+func (r *kqlPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan kqlPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remotePolicy, err := r.alterPolicy(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating KQL policy",
+			"Could not alter KQL policy: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strings.Join([]string{plan.KqlDatabaseID.ValueString(), plan.EntityType.ValueString(), plan.EntityName.ValueString(), plan.PolicyType.ValueString()}, "/"))
+	plan.RemotePolicy = types.StringValue(remotePolicy)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read compares the policy returned by .show policy with the one recorded after the last apply.
+func (r *kqlPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state kqlPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	remotePolicy, err := r.showPolicy(endpoint, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL policy",
+			"Could not read KQL policy: "+err.Error(),
+		)
+		return
+	}
+	if remotePolicy == "" {
+		// The policy was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if remotePolicy != state.RemotePolicy.ValueString() {
+		// Show the policy as it is in the database, so that the plan restores the configured one.
+		state.Policy = types.StringValue(kqlConfiguredPolicy(state.PolicyType.ValueString(), remotePolicy))
+		state.RemotePolicy = types.StringValue(remotePolicy)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update replaces the policy with .alter policy.
+func (r *kqlPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan kqlPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state kqlPolicyResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remotePolicy, err := r.alterPolicy(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating KQL policy",
+			"Could not alter KQL policy: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.RemotePolicy = types.StringValue(remotePolicy)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the policy, so the entity falls back to the inherited or default policy.
+func (r *kqlPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state kqlPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	command := fmt.Sprintf(".delete %s policy %s", kqlPolicyEntity(endpoint, state), state.PolicyType.ValueString())
+	if _, err := executeKqlCommand(r.client, endpoint, command); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting KQL policy",
+			"Could not delete KQL policy: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// alterPolicy sets the planned policy and returns the policy as stored by Kusto.
+func (r *kqlPolicyResource) alterPolicy(model kqlPolicyResourceModel) (string, error) {
+	endpoint, err := getKqlDatabaseEndpoint(r.client, model.WorkspaceID.ValueString(), model.KqlDatabaseID.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("could not read the query service URI of the KQL database: %w", err)
+	}
+
+	entity := kqlPolicyEntity(endpoint, model)
+	policyType := model.PolicyType.ValueString()
+
+	// The caching policy has its own syntax; all other policies take a JSON document.
+	var command string
+	if policyType == "caching" {
+		command = fmt.Sprintf(".alter %s policy caching hot = %s", entity, strings.TrimSpace(model.Policy.ValueString()))
+	} else {
+		command = fmt.Sprintf(".alter %s policy %s %s", entity, policyType, kustoMultilineString(model.Policy.ValueString()))
+	}
+
+	if _, err := executeKqlCommand(r.client, endpoint, command); err != nil {
+		return "", err
+	}
+
+	return r.showPolicy(endpoint, model)
+}
+
+// showPolicy returns the compact policy JSON from .show policy, or an empty string if no policy is set.
+func (r *kqlPolicyResource) showPolicy(endpoint kqlDatabaseEndpoint, model kqlPolicyResourceModel) (string, error) {
+	command := fmt.Sprintf(".show %s policy %s", kqlPolicyEntity(endpoint, model), model.PolicyType.ValueString())
+	rows, err := executeKqlCommand(r.client, endpoint, command)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	policy := kustoRowString(rows[0], "Policy")
+	if policy == "" || policy == "null" {
+		return "", nil
+	}
+
+	return compactJSON(policy), nil
+}
+
+// kqlPolicyEntity returns the entity reference of a policy command, e.g. "table ['Events']".
+func kqlPolicyEntity(endpoint kqlDatabaseEndpoint, model kqlPolicyResourceModel) string {
+	if model.EntityType.ValueString() == "database" {
+		return "database " + kustoName(endpoint.DatabaseName)
+	}
+	return model.EntityType.ValueString() + " " + kustoName(model.EntityName.ValueString())
+}
+
+// kqlConfiguredPolicy converts a policy returned by .show into the format of the policy attribute.
+func kqlConfiguredPolicy(policyType, remotePolicy string) string {
+	if policyType != "caching" {
+		return remotePolicy
+	}
+
+	var caching struct {
+		DataHotSpan struct {
+			Value string `json:"Value"`
+		} `json:"DataHotSpan"`
+	}
+	if err := json.Unmarshal([]byte(remotePolicy), &caching); err != nil || caching.DataHotSpan.Value == "" {
+		return remotePolicy
+	}
+
+	return "time(" + caching.DataHotSpan.Value + ")"
+}
@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// kqlTableResource manages a table of a KQL database.
+type kqlTableResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the KQL table resource.
+func (r *kqlTableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The identifier of the table in the form '<kql database id>/<table name>'.",
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the table. Changing the name recreates the table.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"columns": schema.ListNestedAttribute{
+			Required:    true,
+			Description: "The columns of the table. Removing a column drops it together with its data.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "The name of the column.",
+					},
+					"type": schema.StringAttribute{
+						Required:    true,
+						Description: "The scalar data type of the column: bool, datetime, decimal, dynamic, guid, int, long, real, string or timespan.",
+					},
+				},
+			},
+		},
+		"folder": schema.StringAttribute{
+			Optional:    true,
+			Description: "The folder the table is shown in.",
+		},
+		"docstring": schema.StringAttribute{
+			Optional:    true,
+			Description: "A description of the table.",
+		},
+		"last_updated": schema.StringAttribute{
+			Computed:    true,
+			Description: "The timestamp of the last update.",
+		},
+	}
+	for name, attribute := range kqlDatabaseSchemaAttributes() {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// kqlTableResourceModel defines the model of the KQL table resource.
+type kqlTableResourceModel struct {
+	ID            types.String          `tfsdk:"id"`
+	WorkspaceID   types.String          `tfsdk:"workspace_id"`
+	KqlDatabaseID types.String          `tfsdk:"kql_database_id"`
+	Name          types.String          `tfsdk:"name"`
+	Columns       []kqlTableColumnModel `tfsdk:"columns"`
+	Folder        types.String          `tfsdk:"folder"`
+	Docstring     types.String          `tfsdk:"docstring"`
+	LastUpdated   types.String          `tfsdk:"last_updated"`
+}
+
+type kqlTableColumnModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+// Metadata sets the resource type name.
+func (r *kqlTableResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_kql_table"
+}
+
+// NewKqlTableResource creates a new KQL table resource.
+func NewKqlTableResource(client *apiclient.APIClient) resource.Resource {
+	return &kqlTableResource{client: client}
+}
+
+// Create creates the table with .create-merge table.
+func (r *kqlTableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan kqlTableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, plan.WorkspaceID.ValueString(), plan.KqlDatabaseID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	if _, err := executeKqlCommand(r.client, endpoint, kqlCreateMergeTableCommand(plan)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating KQL table",
+			"Could not create KQL table: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.KqlDatabaseID.ValueString() + "/" + plan.Name.ValueString())
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the columns, folder and docstring with .show commands.
+func (r *kqlTableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state kqlTableResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	// Check that the table exists before asking for its schema, which fails for unknown tables.
+	tables, err := executeKqlCommand(r.client, endpoint, fmt.Sprintf(".show tables | where TableName == %s", kustoString(state.Name.ValueString())))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL table",
+			"Could not read KQL table: "+err.Error(),
+		)
+		return
+	}
+	if len(tables) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	rows, err := executeKqlCommand(r.client, endpoint, fmt.Sprintf(".show table %s schema as json", kustoName(state.Name.ValueString())))
+	if err != nil || len(rows) == 0 {
+		if err == nil {
+			err = fmt.Errorf("empty result")
+		}
+		resp.Diagnostics.AddError(
+			"Error reading KQL table",
+			"Could not read KQL table schema: "+err.Error(),
+		)
+		return
+	}
+
+	// Folder and DocString are result columns of their own, next to the Schema JSON.
+	var tableSchema struct {
+		OrderedColumns []struct {
+			Name    string `json:"Name"`
+			CslType string `json:"CslType"`
+		} `json:"OrderedColumns"`
+	}
+	if err := json.Unmarshal([]byte(kustoRowString(rows[0], "Schema")), &tableSchema); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL table",
+			"Could not parse KQL table schema: "+err.Error(),
+		)
+		return
+	}
+
+	actual := make(map[string]string, len(tableSchema.OrderedColumns))
+	for _, column := range tableSchema.OrderedColumns {
+		actual[column.Name] = column.CslType
+	}
+
+	// .create-merge appends new columns at the end, so the configured order is kept for known columns and
+	// columns added outside of Terraform follow them. Types only differing in casing keep the configured spelling.
+	columns := make([]kqlTableColumnModel, 0, len(tableSchema.OrderedColumns))
+	for _, column := range state.Columns {
+		cslType, ok := actual[column.Name.ValueString()]
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(cslType, column.Type.ValueString()) {
+			column.Type = types.StringValue(cslType)
+		}
+		columns = append(columns, column)
+		delete(actual, column.Name.ValueString())
+	}
+	for _, column := range tableSchema.OrderedColumns {
+		if _, ok := actual[column.Name]; !ok {
+			continue
+		}
+		columns = append(columns, kqlTableColumnModel{
+			Name: types.StringValue(column.Name),
+			Type: types.StringValue(column.CslType),
+		})
+	}
+	state.Columns = columns
+
+	folder := kustoRowString(rows[0], "Folder")
+	if !state.Folder.IsNull() || folder != "" {
+		state.Folder = types.StringValue(folder)
+	}
+	docString := kustoRowString(rows[0], "DocString")
+	if !state.Docstring.IsNull() || docString != "" {
+		state.Docstring = types.StringValue(docString)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update drops removed columns, changes column types and merges the new schema.
+func (r *kqlTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan kqlTableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state kqlTableResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, plan.WorkspaceID.ValueString(), plan.KqlDatabaseID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	tableName := kustoName(plan.Name.ValueString())
+	planned := make(map[string]string, len(plan.Columns))
+	for _, column := range plan.Columns {
+		planned[column.Name.ValueString()] = column.Type.ValueString()
+	}
+
+	var commands []string
+	var dropped []string
+	for _, column := range state.Columns {
+		plannedType, exists := planned[column.Name.ValueString()]
+		if !exists {
+			dropped = append(dropped, kustoName(column.Name.ValueString()))
+			continue
+		}
+		if !strings.EqualFold(plannedType, column.Type.ValueString()) {
+			commands = append(commands, fmt.Sprintf(".alter column %s.%s type = %s", tableName, kustoName(column.Name.ValueString()), plannedType))
+		}
+	}
+	if len(dropped) > 0 {
+		commands = append(commands, fmt.Sprintf(".drop table %s columns (%s)", tableName, strings.Join(dropped, ", ")))
+	}
+
+	// .create-merge adds new columns; folder and docstring are set explicitly so that they can be cleared.
+	commands = append(commands,
+		kqlCreateMergeTableCommand(plan),
+		fmt.Sprintf(".alter table %s folder %s", tableName, kustoString(plan.Folder.ValueString())),
+		fmt.Sprintf(".alter table %s docstring %s", tableName, kustoString(plan.Docstring.ValueString())),
+	)
+
+	for _, command := range commands {
+		if _, err := executeKqlCommand(r.client, endpoint, command); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating KQL table",
+				fmt.Sprintf("Command %q failed: %s", command, err.Error()),
+			)
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete drops the table.
+func (r *kqlTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state kqlTableResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := getKqlDatabaseEndpoint(r.client, state.WorkspaceID.ValueString(), state.KqlDatabaseID.ValueString())
+	if err != nil {
+		// Tables are removed together with their database.
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading KQL database",
+			"Could not read the query service URI of the KQL database: "+err.Error(),
+		)
+		return
+	}
+
+	if _, err := executeKqlCommand(r.client, endpoint, fmt.Sprintf(".drop table %s ifexists", kustoName(state.Name.ValueString()))); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting KQL table",
+			"Could not drop KQL table: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// kqlCreateMergeTableCommand builds the .create-merge table command for the planned schema.
+func kqlCreateMergeTableCommand(model kqlTableResourceModel) string {
+	columns := make([]string, 0, len(model.Columns))
+	for _, column := range model.Columns {
+		columns = append(columns, fmt.Sprintf("%s:%s", kustoName(column.Name.ValueString()), column.Type.ValueString()))
+	}
+
+	var properties []string
+	if !model.Folder.IsNull() {
+		properties = append(properties, "folder = "+kustoString(model.Folder.ValueString()))
+	}
+	if !model.Docstring.IsNull() {
+		properties = append(properties, "docstring = "+kustoString(model.Docstring.ValueString()))
+	}
+
+	command := fmt.Sprintf(".create-merge table %s (%s)", kustoName(model.Name.ValueString()), strings.Join(columns, ", "))
+	if len(properties) > 0 {
+		command += " with (" + strings.Join(properties, ", ") + ")"
+	}
+
+	return command
+}
@@ -2,15 +2,22 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"terraform-provider-microsoftfabric/internal/apiclient"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &kqlDatabaseResource{}
+)
+
 // kqlDatabaseResource defines the resource structure for managing Kql Databases.
 type kqlDatabaseResource struct {
 	client *apiclient.APIClient // API client for making requests.
@@ -37,7 +44,7 @@ func (r *kqlDatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Attributes: map[string]schema.Attribute{
 					"database_type": schema.StringAttribute{
 						Required:    true,
-						Description: "Database type (e.g. ReadWrite or Shortcut).",
+						Description: "Database type: 'ReadWrite' for a regular database, or 'ReadOnlyFollowing' (also accepted as 'Shortcut') for a follower database.",
 					},
 					"parent_eventhouse_items_id": schema.StringAttribute{
 						Required:    true,
@@ -45,29 +52,56 @@ func (r *kqlDatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest
 					},
 					"invitation_token": schema.StringAttribute{
 						Optional:    true,
-						Description: "Invitation token to follow the source database.",
+						Description: "Invitation token to follow the source database. Only for 'ReadOnlyFollowing' databases, instead of source_cluster_uri and source_database_name.",
 					},
 					"source_cluster_uri": schema.StringAttribute{
 						Optional:    true,
-						Description: "The URI of the source Eventhouse or Azure Data Explorer cluster.",
+						Description: "The URI of the source Eventhouse or Azure Data Explorer cluster. Only for 'ReadOnlyFollowing' databases.",
 					},
 					"source_database_name": schema.StringAttribute{
 						Optional:    true,
-						Description: "The name of the database to follow in the source Eventhouse or Azure Data Explorer cluster.",
+						Description: "The name of the database to follow in the source Eventhouse or Azure Data Explorer cluster. Only for 'ReadOnlyFollowing' databases.",
 					},
 				},
 			},
+			"hot_cache_period": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ISO 8601 duration data is kept in the hot cache, e.g. 'P30D'.",
+			},
+			"soft_delete_period": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ISO 8601 duration data is kept available for queries before it is deleted, e.g. 'P365D'.",
+			},
+			"query_service_uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "The query service URI of the database.",
+			},
+			"ingestion_service_uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ingestion service URI of the database.",
+			},
+			"parent_eventhouse_item_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the Eventhouse the database belongs to, as reported by Fabric.",
+			},
 		},
 	}
 }
 
 // kqlDatabaseResourceModel defines the model for managing the Kql Database's state.
 type kqlDatabaseResourceModel struct {
-	ID              types.String         `tfsdk:"id"`               // Unique identifier for the Kql Database
-	WorkspaceID     types.String         `tfsdk:"workspace_id"`     // ID of the workspace the Kql Database belongs to (same as the parent Eventhouse)
-	DisplayName     types.String         `tfsdk:"display_name"`     // Display name of the Kql Database
-	Description     types.String         `tfsdk:"description"`      // Description of the Kql Database
-	CreationPayload creationPayloadModel `tfsdk:"creation_payload"` // Payload for creation
+	ID                     types.String         `tfsdk:"id"`                        // Unique identifier for the Kql Database
+	WorkspaceID            types.String         `tfsdk:"workspace_id"`              // ID of the workspace the Kql Database belongs to (same as the parent Eventhouse)
+	DisplayName            types.String         `tfsdk:"display_name"`              // Display name of the Kql Database
+	Description            types.String         `tfsdk:"description"`               // Description of the Kql Database
+	CreationPayload        creationPayloadModel `tfsdk:"creation_payload"`          // Payload for creation
+	HotCachePeriod         types.String         `tfsdk:"hot_cache_period"`          // Hot cache period (ISO 8601)
+	SoftDeletePeriod       types.String         `tfsdk:"soft_delete_period"`        // Soft delete period (ISO 8601)
+	QueryServiceURI        types.String         `tfsdk:"query_service_uri"`         // Query service URI
+	IngestionServiceURI    types.String         `tfsdk:"ingestion_service_uri"`     // Ingestion service URI
+	ParentEventhouseItemID types.String         `tfsdk:"parent_eventhouse_item_id"` // Parent Eventhouse as reported by Fabric
 }
 
 type creationPayloadModel struct {
@@ -78,17 +112,68 @@ type creationPayloadModel struct {
 	SourceDatabaseName     types.String `tfsdk:"source_database_name"`
 }
 
-// Metadata returns metadata about the Eventhouse resource.
+// Metadata returns metadata about the Kql Database resource.
 func (r *kqlDatabaseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "microsoftfabric_kqldatabase" // Name used in Terraform configuration
 }
 
-// NewKqlDatabaseResource creates a new Eventhouse resource.
+// NewKqlDatabaseResource creates a new Kql Database resource.
 func NewKqlDatabaseResource(client *apiclient.APIClient) resource.Resource {
 	return &kqlDatabaseResource{client: client} // Initialize with API client
 }
 
-// Create implements the creation of an Eventhouse resource.
+// ValidateConfig ensures the follower settings are only used, and complete, for follower databases.
+func (r *kqlDatabaseResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config kqlDatabaseResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload := config.CreationPayload
+	if payload.DatabaseType.IsUnknown() || payload.InvitationToken.IsUnknown() || payload.SourceClusterUri.IsUnknown() || payload.SourceDatabaseName.IsUnknown() {
+		return
+	}
+
+	payloadPath := path.Root("creation_payload")
+	hasToken := !payload.InvitationToken.IsNull()
+	hasSource := !payload.SourceClusterUri.IsNull() || !payload.SourceDatabaseName.IsNull()
+
+	switch payload.DatabaseType.ValueString() {
+	case "ReadWrite":
+		if hasToken || hasSource {
+			resp.Diagnostics.AddAttributeError(
+				payloadPath.AtName("database_type"),
+				"Invalid KQL database configuration",
+				"invitation_token, source_cluster_uri and source_database_name can only be set for 'ReadOnlyFollowing' databases.",
+			)
+		}
+	case "ReadOnlyFollowing", "Shortcut":
+		switch {
+		case hasToken && hasSource:
+			resp.Diagnostics.AddAttributeError(
+				payloadPath.AtName("invitation_token"),
+				"Invalid KQL database configuration",
+				"Set either invitation_token or source_cluster_uri and source_database_name, not both.",
+			)
+		case !hasToken && (payload.SourceClusterUri.IsNull() || payload.SourceDatabaseName.IsNull()):
+			resp.Diagnostics.AddAttributeError(
+				payloadPath,
+				"Invalid KQL database configuration",
+				"A 'ReadOnlyFollowing' database requires invitation_token, or both source_cluster_uri and source_database_name.",
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			payloadPath.AtName("database_type"),
+			"Invalid KQL database configuration",
+			"database_type must be 'ReadWrite' or 'ReadOnlyFollowing'.",
+		)
+	}
+}
+
+// Create implements the creation of a Kql Database resource.
 func (r *kqlDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve the planned state of the resource
 	var plan kqlDatabaseResourceModel
@@ -98,20 +183,41 @@ func (r *kqlDatabaseResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Create the  Kql Database via the API client
-	kqlDatabaseID, err := r.createKqlDatabase(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString(), plan.CreationPayload.DatabaseType.ValueString(), plan.CreationPayload.ParentEventhouseItemId.ValueString(), plan.CreationPayload.InvitationToken.ValueString(), plan.CreationPayload.SourceClusterUri.ValueString(), plan.CreationPayload.SourceDatabaseName.ValueString())
+	// Create the Kql Database via the API client
+	kqlDatabaseID, err := r.createKqlDatabase(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString(), plan.CreationPayload)
 	if err != nil {
 		// Add error diagnostics if creation fails
 		resp.Diagnostics.AddError(
 			"Error creating Kql Database",
-			"Could not create  Kql Database: "+err.Error(),
+			"Could not create Kql Database: "+err.Error(),
 		)
 		return
 	}
 
-	// Set the ID and LastUpdated fields
-	plan.ID = types.StringValue(kqlDatabaseID) // Set the ID returned from the API
-	//plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850)) // Current timestamp
+	// Set the ID returned from the API
+	plan.ID = types.StringValue(kqlDatabaseID)
+
+	// Retention periods are part of the database definition, not of the creation payload
+	if !plan.HotCachePeriod.IsUnknown() || !plan.SoftDeletePeriod.IsUnknown() {
+		if err := r.updateKqlDatabasePeriods(plan); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Kql Database",
+				"Could not set the hot cache and soft delete periods: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Read back the computed properties
+	kqlDatabase, err := r.readKqlDatabase(plan.WorkspaceID.ValueString(), kqlDatabaseID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Kql Database",
+			"Could not read Kql Database: "+err.Error(),
+		)
+		return
+	}
+	setKqlDatabaseProperties(&plan, kqlDatabase)
 
 	// Set the state of the resource in Terraform
 	diags = resp.State.Set(ctx, plan)
@@ -121,7 +227,7 @@ func (r *kqlDatabaseResource) Create(ctx context.Context, req resource.CreateReq
 	}
 }
 
-// Read retrieves the current state of an Eventhouse resource.
+// Read retrieves the current state of a Kql Database resource.
 func (r *kqlDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Retrieve the current state from the resource
 	var state kqlDatabaseResourceModel
@@ -131,21 +237,39 @@ func (r *kqlDatabaseResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Read the Eventhouse details from the API
-	eventhouse, err := r.readKqlDatabase(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	// Read the Kql Database details from the API
+	kqlDatabase, err := r.readKqlDatabase(state.WorkspaceID.ValueString(), state.ID.ValueString())
 	if err != nil {
+		// The database was deleted outside of Terraform
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		// Add error diagnostics if reading fails
 		resp.Diagnostics.AddError(
-			"Error reading Eventhouse",
-			"Could not read Eventhouse: "+err.Error(),
+			"Error reading Kql Database",
+			"Could not read Kql Database: "+err.Error(),
 		)
 		return
 	}
 
+	displayName, ok := kqlDatabase["displayName"].(string)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error reading Kql Database",
+			"Unexpected response format: 'displayName' key not found or not a string",
+		)
+		return
+	}
+	description, _ := kqlDatabase["description"].(string) // description is optional
+
 	// Update state with the values retrieved from the API
-	state.DisplayName = types.StringValue(eventhouse.DisplayName.ValueString())
-	state.Description = types.StringValue(eventhouse.Description.ValueString())
-	//state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850)) // Update last modified timestamp
+	state.DisplayName = types.StringValue(displayName)
+	if !state.Description.IsNull() || description != "" {
+		state.Description = types.StringValue(description)
+	}
+	setKqlDatabaseProperties(&state, kqlDatabase)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -154,7 +278,7 @@ func (r *kqlDatabaseResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 }
 
-// Update modifies an existing Eventhouse resource.
+// Update modifies an existing Kql Database resource.
 func (r *kqlDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan kqlDatabaseResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -170,20 +294,42 @@ func (r *kqlDatabaseResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// Update the Eventhouse with new details
+	plan.ID = state.ID // Ensure the ID remains unchanged
+
+	// Update the Kql Database with new details
 	err := r.updateKqlDatabase(plan.WorkspaceID.ValueString(), state.ID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString())
 	if err != nil {
 		// Add error diagnostics if updating fails
 		resp.Diagnostics.AddError(
-			"Error updating Eventhouse",
-			"Could not update Eventhouse: "+err.Error(),
+			"Error updating Kql Database",
+			"Could not update Kql Database: "+err.Error(),
 		)
 		return
 	}
 
-	// Set LastUpdated field to current timestamp
-	plan.ID = state.ID // Ensure the ID remains unchanged
-	//plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	// Update the retention periods only when they were changed in the configuration
+	periodsChanged := (!plan.HotCachePeriod.IsUnknown() && !plan.HotCachePeriod.Equal(state.HotCachePeriod)) ||
+		(!plan.SoftDeletePeriod.IsUnknown() && !plan.SoftDeletePeriod.Equal(state.SoftDeletePeriod))
+	if periodsChanged {
+		if err := r.updateKqlDatabasePeriods(plan); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Kql Database",
+				"Could not update the hot cache and soft delete periods: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Read back the computed properties
+	kqlDatabase, err := r.readKqlDatabase(plan.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Kql Database",
+			"Could not read Kql Database: "+err.Error(),
+		)
+		return
+	}
+	setKqlDatabaseProperties(&plan, kqlDatabase)
 
 	// Set the updated state
 	diags = resp.State.Set(ctx, plan)
@@ -193,7 +339,7 @@ func (r *kqlDatabaseResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 }
 
-// Delete removes an Eventhouse resource.
+// Delete removes a Kql Database resource.
 func (r *kqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state kqlDatabaseResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -207,8 +353,8 @@ func (r *kqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteReq
 	if err != nil {
 		// Add error diagnostics if deletion fails
 		resp.Diagnostics.AddError(
-			"Error deleting Eventhouse",
-			"Could not delete Eventhouse: "+err.Error(),
+			"Error deleting Kql Database",
+			"Could not delete Kql Database: "+err.Error(),
 		)
 		return
 	}
@@ -217,30 +363,63 @@ func (r *kqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteReq
 	resp.State.RemoveResource(ctx)
 }
 
-// createKqlDatabase sends a request to create a new  Kql Database in the specified workspace.
-func (r *kqlDatabaseResource) createKqlDatabase(workspaceID, displayName, description, databaseType, parentEventhouseItemId, invitationToken, sourceClusterUri, sourceDatabaseName string) (string, error) {
-	// URL for the API endpoint to create an  Kql Database
+// setKqlDatabaseProperties copies the computed Kql Database properties from an API response into the model.
+func setKqlDatabaseProperties(model *kqlDatabaseResourceModel, kqlDatabase map[string]interface{}) {
+	properties, _ := kqlDatabase["properties"].(map[string]interface{})
+
+	queryServiceURI, _ := getMapString("queryServiceUri", properties)
+	ingestionServiceURI, _ := getMapString("ingestionServiceUri", properties)
+	parentEventhouseItemID, _ := getMapString("parentEventhouseItemId", properties)
+	hotCachePeriod, _ := getMapString("oneLakeCachingPeriod", properties)
+	softDeletePeriod, _ := getMapString("oneLakeStandardStoragePeriod", properties)
+
+	model.QueryServiceURI = types.StringValue(queryServiceURI)
+	model.IngestionServiceURI = types.StringValue(ingestionServiceURI)
+	model.ParentEventhouseItemID = types.StringValue(parentEventhouseItemID)
+	model.HotCachePeriod = types.StringValue(hotCachePeriod)
+	model.SoftDeletePeriod = types.StringValue(softDeletePeriod)
+}
+
+// createKqlDatabase sends a request to create a new Kql Database in the specified workspace.
+func (r *kqlDatabaseResource) createKqlDatabase(workspaceID, displayName, description string, payload creationPayloadModel) (string, error) {
+	// URL for the API endpoint to create a Kql Database
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/kqlDatabases", workspaceID)
+
+	// Follower databases are created as database shortcuts
+	databaseType := payload.DatabaseType.ValueString()
+	if databaseType == "ReadOnlyFollowing" {
+		databaseType = "Shortcut"
+	}
+
+	// Only send the fields that are set; the API rejects empty follower settings
+	creationPayload := map[string]string{
+		"databaseType":           databaseType,
+		"parentEventhouseItemId": payload.ParentEventhouseItemId.ValueString(),
+	}
+	if value := payload.InvitationToken.ValueString(); value != "" {
+		creationPayload["invitationToken"] = value
+	}
+	if value := payload.SourceClusterUri.ValueString(); value != "" {
+		creationPayload["sourceClusterUri"] = value
+	}
+	if value := payload.SourceDatabaseName.ValueString(); value != "" {
+		creationPayload["sourceDatabaseName"] = value
+	}
+
 	body := map[string]interface{}{
-		"displayName": displayName, // Set the display name
-		"description": description, // Set the description
-		"creationPayload": map[string]string{
-			"databaseType":           databaseType,
-			"parentEventhouseItemId": parentEventhouseItemId,
-			"invitationToken":        invitationToken,
-			"sourceClusterUri":       sourceClusterUri,
-			"sourceDatabaseName":     sourceDatabaseName,
-		},
+		"displayName":     displayName, // Set the display name
+		"description":     description, // Set the description
+		"creationPayload": creationPayload,
 	}
 
-	// Make a POST request to create the  Kql Database
-	responseBody, err := r.client.PostWithOperationCheck(url, body)
+	// Make a POST request to create the Kql Database and wait for the provisioning to finish
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
 	if err != nil {
 		return "", fmt.Errorf("failed to make POST request: %w", err) // Return the error on failure
 	}
 
-	// Extract the  Kql Database ID from the response
-	kqlDatabaseID, ok := responseBody["id"].(string) // Modify based on the actual response structure
+	// Extract the Kql Database ID from the response
+	kqlDatabaseID, ok := responseBody["id"].(string)
 	if !ok {
 		return "", fmt.Errorf("unexpected response format: 'id' key not found") // Handle unexpected response
 	}
@@ -248,29 +427,16 @@ func (r *kqlDatabaseResource) createKqlDatabase(workspaceID, displayName, descri
 	return kqlDatabaseID, nil // Return the newly created Kql Database ID
 }
 
-// readKqlDatabase retrieves the details of an existing  Kql Database.
-func (r *kqlDatabaseResource) readKqlDatabase(workspaceID, kqlDatabaseID string) (kqlDatabaseResourceModel, error) {
-	// URL for the API endpoint to read an  Kql Database
+// readKqlDatabase retrieves the details of an existing Kql Database.
+func (r *kqlDatabaseResource) readKqlDatabase(workspaceID, kqlDatabaseID string) (map[string]interface{}, error) {
+	// URL for the API endpoint to read a Kql Database
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/kqlDatabases/%s", workspaceID, kqlDatabaseID)
-
-	responseBody, err := r.client.Get(url) // Fetch Kql Database details from API
-	if err != nil {
-		return kqlDatabaseResourceModel{}, err // Return error if fetching fails
-	}
-
-	// Map the response data to the Kql Database model
-	kqlDatabase := kqlDatabaseResourceModel{
-		ID:          types.StringValue(responseBody["id"].(string)),          // Kql Database ID
-		DisplayName: types.StringValue(responseBody["displayName"].(string)), // Kql Database display name
-		Description: types.StringValue(responseBody["description"].(string)), // Kql Database description
-	}
-
-	return kqlDatabase, nil // Return the populated Kql Database model
+	return r.client.Get(url) // Fetch Kql Database details from API
 }
 
 // updateKqlDatabase sends a request to update an existing Kql Database.
 func (r *kqlDatabaseResource) updateKqlDatabase(workspaceID, kqlDatabaseID, displayName, description string) error {
-	// URL for the API endpoint to update an Kql Database
+	// URL for the API endpoint to update a Kql Database
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/kqlDatabases/%s", workspaceID, kqlDatabaseID)
 	body := map[string]interface{}{
 		"displayName": displayName, // New display name for the Kql Database
@@ -281,9 +447,53 @@ func (r *kqlDatabaseResource) updateKqlDatabase(workspaceID, kqlDatabaseID, disp
 	return err                          // Return any error encountered
 }
 
+// updateKqlDatabasePeriods writes the hot cache and soft delete periods through DatabaseProperties.json.
+// The part is exported first and only the periods are changed, so the other database properties are kept.
+func (r *kqlDatabaseResource) updateKqlDatabasePeriods(model kqlDatabaseResourceModel) error {
+	exported, err := getItemDefinition(r.client, model.WorkspaceID.ValueString(), model.ID.ValueString(), "")
+	if err != nil {
+		return fmt.Errorf("failed to export the database definition: %w", err)
+	}
+
+	var properties map[string]interface{}
+	for _, part := range exported {
+		if part.Path != "DatabaseProperties.json" {
+			continue
+		}
+		if err := json.Unmarshal(part.Payload, &properties); err != nil {
+			return fmt.Errorf("failed to parse DatabaseProperties.json: %w", err)
+		}
+	}
+
+	if properties == nil {
+		databaseType := model.CreationPayload.DatabaseType.ValueString()
+		if databaseType == "Shortcut" {
+			databaseType = "ReadOnlyFollowing"
+		}
+		properties = map[string]interface{}{
+			"databaseType":           databaseType,
+			"parentEventhouseItemId": model.CreationPayload.ParentEventhouseItemId.ValueString(),
+		}
+	}
+	if !model.HotCachePeriod.IsUnknown() && !model.HotCachePeriod.IsNull() {
+		properties["oneLakeCachingPeriod"] = model.HotCachePeriod.ValueString()
+	}
+	if !model.SoftDeletePeriod.IsUnknown() && !model.SoftDeletePeriod.IsNull() {
+		properties["oneLakeStandardStoragePeriod"] = model.SoftDeletePeriod.ValueString()
+	}
+
+	payload, err := json.Marshal(properties)
+	if err != nil {
+		return fmt.Errorf("failed to marshal DatabaseProperties.json: %w", err)
+	}
+
+	parts := []definitionPart{{Path: "DatabaseProperties.json", Payload: payload}}
+	return updateItemDefinition(r.client, model.WorkspaceID.ValueString(), model.ID.ValueString(), "", parts)
+}
+
 // deleteKqlDatabase sends a request to delete an existing Kql Database.
 func (r *kqlDatabaseResource) deleteKqlDatabase(workspaceID, kqlDatabaseID string) error {
-	// URL for the API endpoint to delete an Kql Database
+	// URL for the API endpoint to delete a Kql Database
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/kqlDatabases/%s", workspaceID, kqlDatabaseID)
 	return r.client.Delete(url) // Make DELETE request and return any error
 }
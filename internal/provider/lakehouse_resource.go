@@ -8,9 +8,15 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// lakehouseSQLEndpointTimeout is how long Create waits for the SQL endpoint of a new lakehouse to be provisioned.
+const lakehouseSQLEndpointTimeout = 15 * time.Minute
+
 // Define the resource struct.
 type lakehouseResource struct {
 	client *apiclient.APIClient
@@ -36,6 +42,22 @@ func (r *lakehouseResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Description: "An optional description of the lakehouse resource, providing more context about its purpose.",
 			},
+			"enable_schemas": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the lakehouse organizes its tables in schemas. Can only be set when the lakehouse is created.",
+				PlanModifiers: []planmodifier.Bool{
+					// Lakehouses created before this attribute existed have no value in the state.
+					boolplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull()
+					}, "Changing enable_schemas requires replacing the lakehouse.", "Changing enable_schemas requires replacing the lakehouse."),
+				},
+			},
+			"default_schema": schema.StringAttribute{
+				Computed:    true,
+				Description: "The default schema of a lakehouse with schemas enabled, e.g. 'dbo'.",
+			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "The timestamp of the last update made to the lakehouse resource.",
@@ -44,9 +66,21 @@ func (r *lakehouseResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Description: "Path for OneLake tables associated with the lakehouse.",
 			},
+			"one_lake_files_path": schema.StringAttribute{
+				Computed:    true,
+				Description: "Path for OneLake files associated with the lakehouse.",
+			},
 			"sql_connection_string": schema.StringAttribute{
 				Computed:    true,
-				Description: "Connection string for SQL endpoint associated with the lakehouse. The creation of this endpoints takes some time. Thus this resource needs about 15 seconds to be created",
+				Description: "Connection string for SQL endpoint associated with the lakehouse. Creating the lakehouse waits until the SQL endpoint is provisioned.",
+			},
+			"sql_endpoint_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the SQL endpoint associated with the lakehouse.",
+			},
+			"sql_endpoint_provisioning_status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The provisioning status of the SQL endpoint, e.g. 'InProgress', 'Success' or 'Failed'.",
 			},
 		},
 	}
@@ -54,13 +88,18 @@ func (r *lakehouseResource) Schema(_ context.Context, _ resource.SchemaRequest,
 
 // Define the model for the lakehouse resource.
 type lakehouseResourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	WorkspaceID         types.String `tfsdk:"workspace_id"`
-	DisplayName         types.String `tfsdk:"display_name"`
-	Description         types.String `tfsdk:"description"`
-	LastUpdated         types.String `tfsdk:"last_updated"`
-	OneLakeTablesPath   types.String `tfsdk:"one_lake_tables_path"`
-	SqlConnectionString types.String `tfsdk:"sql_connection_string"`
+	ID                            types.String `tfsdk:"id"`
+	WorkspaceID                   types.String `tfsdk:"workspace_id"`
+	DisplayName                   types.String `tfsdk:"display_name"`
+	Description                   types.String `tfsdk:"description"`
+	EnableSchemas                 types.Bool   `tfsdk:"enable_schemas"`
+	DefaultSchema                 types.String `tfsdk:"default_schema"`
+	LastUpdated                   types.String `tfsdk:"last_updated"`
+	OneLakeTablesPath             types.String `tfsdk:"one_lake_tables_path"`
+	OneLakeFilesPath              types.String `tfsdk:"one_lake_files_path"`
+	SqlConnectionString           types.String `tfsdk:"sql_connection_string"`
+	SqlEndpointID                 types.String `tfsdk:"sql_endpoint_id"`
+	SqlEndpointProvisioningStatus types.String `tfsdk:"sql_endpoint_provisioning_status"`
 }
 
 // Implement Metadata method.
@@ -93,7 +132,7 @@ func (r *lakehouseResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Create lakehouse.
-	lakehouseID, err := r.createLakehouse(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString())
+	lakehouseID, err := r.createLakehouse(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString(), plan.EnableSchemas.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating lakehouse",
@@ -102,33 +141,24 @@ func (r *lakehouseResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// Wait for a short period before trying to read back
-	time.Sleep(15 * time.Second) // Adjust this as necessary.
-
-	// Read back the newly created lakehouse to get all known attributes.
-	createdLakehouse, err := r.readLakehouse(plan.WorkspaceID.ValueString(), lakehouseID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading newly created lakehouse",
-			"Could not read lakehouse: "+err.Error(),
-		)
-		return
-	}
-
 	// Set ID and LastUpdated fields.
 	plan.ID = types.StringValue(lakehouseID)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
-	// Use utility function for safe retrieval.
-	properties := createdLakehouse["properties"].(map[string]interface{})
-	if oneLakeTablesPath, ok := getMapString("oneLakeTablesPath", properties); ok {
-		plan.OneLakeTablesPath = types.StringValue(oneLakeTablesPath)
+	// Wait until the SQL endpoint is provisioned, so that dependent resources such as semantic models can use it.
+	createdLakehouse, err := r.waitForSQLEndpoint(plan.WorkspaceID.ValueString(), lakehouseID)
+	if createdLakehouse != nil {
+		setLakehouseProperties(&plan, createdLakehouse)
 	}
-
-	if sqlEndpointProperties, ok := properties["sqlEndpointProperties"].(map[string]interface{}); ok {
-		if connectionString, ok := getMapString("connectionString", sqlEndpointProperties); ok {
-			plan.SqlConnectionString = types.StringValue(connectionString)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error provisioning lakehouse SQL endpoint",
+			fmt.Sprintf("Lakehouse %s was created, but its SQL endpoint is not ready: %s", lakehouseID, err.Error()),
+		)
+		if createdLakehouse == nil {
+			return
 		}
+		// Save the lakehouse, Terraform marks it as tainted because of the error.
 	}
 
 	// Set state.
@@ -151,6 +181,10 @@ func (r *lakehouseResource) Read(ctx context.Context, req resource.ReadRequest,
 	// Read lakehouse from API.
 	lakehouse, err := r.readLakehouse(state.WorkspaceID.ValueString(), state.ID.ValueString())
 	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading lakehouse",
 			"Could not read lakehouse: "+err.Error(),
@@ -180,26 +214,13 @@ func (r *lakehouseResource) Read(ctx context.Context, req resource.ReadRequest,
 	description, _ := lakehouse["description"].(string) // defaults to empty string if not found
 	lastUpdated := time.Now().Format(time.RFC850)
 
-	// Extract additional properties.
-	var oneLakeTablesPath, sqlConnectionString types.String
-	if properties, ok := lakehouse["properties"].(map[string]interface{}); ok {
-		if oneLakeTablesPathVal, ok := properties["oneLakeTablesPath"].(string); ok {
-			oneLakeTablesPath = types.StringValue(oneLakeTablesPathVal)
-		}
-		if sqlEndpointProperties, ok := properties["sqlEndpointProperties"].(map[string]interface{}); ok {
-			if connectionString, ok := sqlEndpointProperties["connectionString"].(string); ok {
-				sqlConnectionString = types.StringValue(connectionString)
-			}
-		}
-	}
-
 	// Set state with the response values.
 	state.ID = types.StringValue(id)
 	state.DisplayName = types.StringValue(displayName)
 	state.Description = types.StringValue(description)
 	state.LastUpdated = types.StringValue(lastUpdated)
-	state.OneLakeTablesPath = oneLakeTablesPath
-	state.SqlConnectionString = sqlConnectionString
+	setLakehouseProperties(&state, lakehouse)
+	state.EnableSchemas = types.BoolValue(!state.DefaultSchema.IsNull())
 
 	// Set the state.
 	diags = resp.State.Set(ctx, state)
@@ -251,16 +272,7 @@ func (r *lakehouseResource) Update(ctx context.Context, req resource.UpdateReque
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Extract updated values.
-	properties := updatedLakehouse["properties"].(map[string]interface{})
-	if oneLakeTablesPath, ok := getMapString("oneLakeTablesPath", properties); ok {
-		plan.OneLakeTablesPath = types.StringValue(oneLakeTablesPath)
-	}
-
-	if sqlEndpointProperties, ok := properties["sqlEndpointProperties"].(map[string]interface{}); ok {
-		if connectionString, ok := getMapString("connectionString", sqlEndpointProperties); ok {
-			plan.SqlConnectionString = types.StringValue(connectionString)
-		}
-	}
+	setLakehouseProperties(&plan, updatedLakehouse)
 
 	// Set state.
 	diags = resp.State.Set(ctx, plan)
@@ -294,22 +306,24 @@ func (r *lakehouseResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 // Helper functions for lakehouse operations.
-func (r *lakehouseResource) createLakehouse(workspaceID, displayName, description string) (string, error) {
+func (r *lakehouseResource) createLakehouse(workspaceID, displayName, description string, enableSchemas bool) (string, error) {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/lakehouses", workspaceID)
 	body := map[string]interface{}{
 		"displayName": displayName,
 		"description": description,
 	}
+	if enableSchemas {
+		body["creationPayload"] = map[string]interface{}{
+			"enableSchemas": true,
+		}
+	}
 
-	// Send the POST request using the existing Post method from the API client.
-	responseBody, err := r.client.Post(url, body)
+	// Creating a lakehouse may run as a long-running operation that returns the lakehouse when it finishes.
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
 	if err != nil {
 		return "", fmt.Errorf("error during POST request: %w", err)
 	}
 
-	// Log the full response body for debugging.
-	fmt.Printf("Full Response Body: %+v\n", responseBody)
-
 	// Extract the lakehouse ID from the response.
 	lakehouseID, ok := responseBody["id"].(string)
 	if !ok {
@@ -319,6 +333,79 @@ func (r *lakehouseResource) createLakehouse(workspaceID, displayName, descriptio
 	return lakehouseID, nil
 }
 
+// waitForSQLEndpoint polls the lakehouse until the provisioning status of its SQL endpoint is Success.
+// The last read lakehouse is returned together with an error if provisioning fails or takes too long.
+func (r *lakehouseResource) waitForSQLEndpoint(workspaceID, lakehouseID string) (map[string]interface{}, error) {
+	deadline := time.Now().Add(lakehouseSQLEndpointTimeout)
+	var lakehouse map[string]interface{}
+
+	for {
+		read, err := r.readLakehouse(workspaceID, lakehouseID)
+		if err != nil {
+			return lakehouse, err
+		}
+		lakehouse = read
+
+		status := ""
+		if properties, ok := lakehouse["properties"].(map[string]interface{}); ok {
+			if sqlEndpointProperties, ok := properties["sqlEndpointProperties"].(map[string]interface{}); ok {
+				status, _ = getMapString("provisioningStatus", sqlEndpointProperties)
+			}
+		}
+
+		switch status {
+		case "Success":
+			return lakehouse, nil
+		case "Failed":
+			return lakehouse, fmt.Errorf("provisioning of the SQL endpoint failed")
+		}
+
+		if time.Now().After(deadline) {
+			return lakehouse, fmt.Errorf("the SQL endpoint was not provisioned within %s, last status %q", lakehouseSQLEndpointTimeout, status)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// setLakehouseProperties copies the properties of a lakehouse returned by the API into the model.
+func setLakehouseProperties(model *lakehouseResourceModel, lakehouse map[string]interface{}) {
+	model.OneLakeTablesPath = types.StringNull()
+	model.OneLakeFilesPath = types.StringNull()
+	model.DefaultSchema = types.StringNull()
+	model.SqlConnectionString = types.StringNull()
+	model.SqlEndpointID = types.StringNull()
+	model.SqlEndpointProvisioningStatus = types.StringNull()
+
+	properties, ok := lakehouse["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if oneLakeTablesPath, ok := getMapString("oneLakeTablesPath", properties); ok {
+		model.OneLakeTablesPath = types.StringValue(oneLakeTablesPath)
+	}
+	if oneLakeFilesPath, ok := getMapString("oneLakeFilesPath", properties); ok {
+		model.OneLakeFilesPath = types.StringValue(oneLakeFilesPath)
+	}
+
+	// Only lakehouses with schemas enabled have a default schema.
+	if defaultSchema, ok := getMapString("defaultSchema", properties); ok {
+		model.DefaultSchema = types.StringValue(defaultSchema)
+	}
+
+	if sqlEndpointProperties, ok := properties["sqlEndpointProperties"].(map[string]interface{}); ok {
+		if connectionString, ok := getMapString("connectionString", sqlEndpointProperties); ok {
+			model.SqlConnectionString = types.StringValue(connectionString)
+		}
+		if sqlEndpointID, ok := getMapString("id", sqlEndpointProperties); ok {
+			model.SqlEndpointID = types.StringValue(sqlEndpointID)
+		}
+		if provisioningStatus, ok := getMapString("provisioningStatus", sqlEndpointProperties); ok {
+			model.SqlEndpointProvisioningStatus = types.StringValue(provisioningStatus)
+		}
+	}
+}
+
 func (r *lakehouseResource) readLakehouse(workspaceID, lakehouseID string) (map[string]interface{}, error) {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/lakehouses/%s", workspaceID, lakehouseID)
 	return r.client.Get(url)
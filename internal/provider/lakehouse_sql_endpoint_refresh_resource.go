@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sqlEndpointTableStatusType is the object type of the sync status of one table.
+var sqlEndpointTableStatusType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"table_name":                     types.StringType,
+		"status":                         types.StringType,
+		"start_date_time":                types.StringType,
+		"end_date_time":                  types.StringType,
+		"last_successful_sync_date_time": types.StringType,
+		"error":                          types.StringType,
+	},
+}
+
+// lakehouseSQLEndpointRefreshResource refreshes the metadata of the SQL analytics endpoint of a lakehouse,
+// so that new tables and shortcuts can be queried. Every run is a new resource instance: changing any
+// argument, including triggers, refreshes again.
+type lakehouseSQLEndpointRefreshResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the lakehouse SQL endpoint refresh resource.
+func (r *lakehouseSQLEndpointRefreshResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Refreshes the metadata of the SQL analytics endpoint of a lakehouse and fails when a table cannot be synchronized. Changing any argument, including triggers, refreshes again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the refreshed SQL endpoint.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the lakehouse.",
+				PlanModifiers: requiresReplace,
+			},
+			"lakehouse_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the lakehouse whose SQL endpoint is refreshed.",
+				PlanModifiers: requiresReplace,
+			},
+			"timeout_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum time the service spends on the refresh, in minutes.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that cause a new refresh when they change, e.g. the operation ID of a table load.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"sql_endpoint_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the SQL endpoint of the lakehouse.",
+			},
+			"tables": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The sync status of every table of the SQL endpoint.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"table_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the table.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The sync status of the table: 'Success', 'Failure' or 'NotRun'.",
+						},
+						"start_date_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The start of the sync of the table.",
+						},
+						"end_date_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The end of the sync of the table.",
+						},
+						"last_successful_sync_date_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The end of the last successful sync of the table.",
+						},
+						"error": schema.StringAttribute{
+							Computed:    true,
+							Description: "The error of a failed sync.",
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the refresh.",
+			},
+		},
+	}
+}
+
+// lakehouseSQLEndpointRefreshResourceModel defines the model of the lakehouse SQL endpoint refresh resource.
+type lakehouseSQLEndpointRefreshResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	WorkspaceID    types.String `tfsdk:"workspace_id"`
+	LakehouseID    types.String `tfsdk:"lakehouse_id"`
+	TimeoutMinutes types.Int64  `tfsdk:"timeout_minutes"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	SQLEndpointID  types.String `tfsdk:"sql_endpoint_id"`
+	Tables         types.List   `tfsdk:"tables"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+}
+
+// sqlEndpointTableStatusModel is the sync status of one table of the SQL endpoint.
+type sqlEndpointTableStatusModel struct {
+	TableName                  types.String `tfsdk:"table_name"`
+	Status                     types.String `tfsdk:"status"`
+	StartDateTime              types.String `tfsdk:"start_date_time"`
+	EndDateTime                types.String `tfsdk:"end_date_time"`
+	LastSuccessfulSyncDateTime types.String `tfsdk:"last_successful_sync_date_time"`
+	Error                      types.String `tfsdk:"error"`
+}
+
+// Metadata sets the resource type name.
+func (r *lakehouseSQLEndpointRefreshResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_lakehouse_sql_endpoint_refresh"
+}
+
+// NewLakehouseSQLEndpointRefreshResource creates a new lakehouse SQL endpoint refresh resource.
+func NewLakehouseSQLEndpointRefreshResource(client *apiclient.APIClient) resource.Resource {
+	return &lakehouseSQLEndpointRefreshResource{client: client}
+}
+
+// Create refreshes the SQL endpoint metadata and waits for it to finish.
+func (r *lakehouseSQLEndpointRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan lakehouseSQLEndpointRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sqlEndpointID, err := r.getSQLEndpointID(plan.WorkspaceID.ValueString(), plan.LakehouseID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error refreshing SQL endpoint metadata",
+			fmt.Sprintf("Could not find the SQL endpoint of lakehouse %s: %s", plan.LakehouseID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/sqlEndpoints/%s/refreshMetadata", plan.WorkspaceID.ValueString(), sqlEndpointID)
+	body := map[string]interface{}{}
+	if !plan.TimeoutMinutes.IsNull() {
+		body["timeout"] = map[string]interface{}{
+			"timeUnit": "Minutes",
+			"value":    plan.TimeoutMinutes.ValueInt64(),
+		}
+	}
+
+	result, err := r.client.PostWithLongRunningOperation(url, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error refreshing SQL endpoint metadata",
+			fmt.Sprintf("Refreshing SQL endpoint %s failed: %s", sqlEndpointID, err.Error()),
+		)
+		return
+	}
+
+	tables, failures := sqlEndpointTableStatuses(result)
+	if len(failures) > 0 {
+		resp.Diagnostics.AddError(
+			"Error refreshing SQL endpoint metadata",
+			fmt.Sprintf("The metadata of %d table(s) could not be synchronized:\n%s", len(failures), strings.Join(failures, "\n")),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(sqlEndpointID)
+	plan.SQLEndpointID = types.StringValue(sqlEndpointID)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.Tables, diags = types.ListValueFrom(ctx, sqlEndpointTableStatusType, tables)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded refresh; a refresh cannot drift.
+func (r *lakehouseSQLEndpointRefreshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state lakehouseSQLEndpointRefreshResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called because every argument requires replacement.
+func (r *lakehouseSQLEndpointRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan lakehouseSQLEndpointRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the refresh from the state.
+func (r *lakehouseSQLEndpointRefreshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+// getSQLEndpointID reads the ID of the SQL endpoint of a lakehouse.
+func (r *lakehouseSQLEndpointRefreshResource) getSQLEndpointID(workspaceID, lakehouseID string) (string, error) {
+	lakehouse, err := r.client.Get(fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/lakehouses/%s", workspaceID, lakehouseID))
+	if err != nil {
+		return "", err
+	}
+
+	properties, _ := lakehouse["properties"].(map[string]interface{})
+	sqlEndpointProperties, _ := properties["sqlEndpointProperties"].(map[string]interface{})
+	sqlEndpointID, ok := getMapString("id", sqlEndpointProperties)
+	if !ok || sqlEndpointID == "" {
+		return "", fmt.Errorf("the lakehouse has no SQL endpoint yet")
+	}
+	return sqlEndpointID, nil
+}
+
+// sqlEndpointTableStatuses converts the result of a metadata refresh into table statuses and returns a
+// description of every failed table.
+func sqlEndpointTableStatuses(result map[string]interface{}) ([]sqlEndpointTableStatusModel, []string) {
+	values, _ := result["value"].([]interface{})
+	tables := make([]sqlEndpointTableStatusModel, 0, len(values))
+	var failures []string
+
+	optional := func(m map[string]interface{}, key string) types.String {
+		if value, ok := getMapString(key, m); ok {
+			return types.StringValue(value)
+		}
+		return types.StringNull()
+	}
+
+	for _, value := range values {
+		table, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		tableName, _ := getMapString("tableName", table)
+		status, _ := getMapString("status", table)
+
+		errorValue := types.StringNull()
+		if tableError, ok := table["error"].(map[string]interface{}); ok {
+			errorCode, _ := getMapString("errorCode", tableError)
+			message, _ := getMapString("message", tableError)
+			errorValue = types.StringValue(strings.TrimPrefix(errorCode+": "+message, ": "))
+		}
+		if status == "Failure" {
+			failures = append(failures, fmt.Sprintf("- %s: %s", tableName, errorValue.ValueString()))
+		}
+
+		tables = append(tables, sqlEndpointTableStatusModel{
+			TableName:                  types.StringValue(tableName),
+			Status:                     types.StringValue(status),
+			StartDateTime:              optional(table, "startDateTime"),
+			EndDateTime:                optional(table, "endDateTime"),
+			LastSuccessfulSyncDateTime: optional(table, "lastSuccessfulSyncDateTime"),
+			Error:                      errorValue,
+		})
+	}
+
+	return tables, failures
+}
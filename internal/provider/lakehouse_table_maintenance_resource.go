@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &lakehouseTableMaintenanceResource{}
+)
+
+// tableMaintenanceJobType is the Fabric job type that runs OPTIMIZE and VACUUM on a lakehouse table.
+const tableMaintenanceJobType = "TableMaintenance"
+
+// vacuumRetentionPeriodPattern matches retention periods in the "d.hh:mm:ss" format, e.g. "7.01:00:00".
+var vacuumRetentionPeriodPattern = regexp.MustCompile(`^\d+\.\d{2}:\d{2}:\d{2}$`)
+
+// lakehouseTableMaintenanceResource runs the TableMaintenance job for a lakehouse table. Fabric job schedules
+// belong to the whole lakehouse and carry no execution data, so the job cannot be scheduled per table.
+type lakehouseTableMaintenanceResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the lakehouse table maintenance resource.
+func (r *lakehouseTableMaintenanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Runs the TableMaintenance job (OPTIMIZE with optional V-Order and Z-Order, and VACUUM) for a lakehouse table and waits for it to complete. Changing any maintenance setting or the triggers runs the job again. Recurring maintenance is not supported, because Fabric job schedules apply to the whole lakehouse and cannot carry the table settings.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the maintenance in the form '<workspace id>/<lakehouse id>/<table name>'.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the lakehouse.",
+				PlanModifiers: requiresReplace,
+			},
+			"lakehouse_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the lakehouse that contains the table.",
+				PlanModifiers: requiresReplace,
+			},
+			"table_name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the table to maintain.",
+				PlanModifiers: requiresReplace,
+			},
+			"schema_name": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The schema of the table, for lakehouses with schemas enabled.",
+				PlanModifiers: requiresReplace,
+			},
+			"optimize": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether to run OPTIMIZE to compact small files. Defaults to true.",
+			},
+			"v_order": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether OPTIMIZE applies V-Order to the rewritten files.",
+			},
+			"z_order_by": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The columns OPTIMIZE co-locates data by (Z-Order).",
+			},
+			"vacuum_retention_period": schema.StringAttribute{
+				Optional:    true,
+				Description: "When set, VACUUM removes files that are no longer referenced and older than this period, in the format 'd.hh:mm:ss', e.g. '7.01:00:00'.",
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that run the job again when they change.",
+			},
+			"job_instance_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the last job instance.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the last job instance.",
+			},
+			"start_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The start time of the last job instance in UTC.",
+			},
+			"end_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The end time of the last job instance in UTC.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last run of the job.",
+			},
+		},
+	}
+}
+
+// lakehouseTableMaintenanceResourceModel defines the model of the lakehouse table maintenance resource.
+type lakehouseTableMaintenanceResourceModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	WorkspaceID           types.String   `tfsdk:"workspace_id"`
+	LakehouseID           types.String   `tfsdk:"lakehouse_id"`
+	TableName             types.String   `tfsdk:"table_name"`
+	SchemaName            types.String   `tfsdk:"schema_name"`
+	Optimize              types.Bool     `tfsdk:"optimize"`
+	VOrder                types.Bool     `tfsdk:"v_order"`
+	ZOrderBy              []types.String `tfsdk:"z_order_by"`
+	VacuumRetentionPeriod types.String   `tfsdk:"vacuum_retention_period"`
+	Triggers              types.Map      `tfsdk:"triggers"`
+	JobInstanceID         types.String   `tfsdk:"job_instance_id"`
+	Status                types.String   `tfsdk:"status"`
+	StartTime             types.String   `tfsdk:"start_time"`
+	EndTime               types.String   `tfsdk:"end_time"`
+	LastUpdated           types.String   `tfsdk:"last_updated"`
+}
+
+// Metadata sets the resource type name.
+func (r *lakehouseTableMaintenanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_lakehouse_table_maintenance"
+}
+
+// NewLakehouseTableMaintenanceResource creates the lakehouse table maintenance resource.
+func NewLakehouseTableMaintenanceResource(client *apiclient.APIClient) resource.Resource {
+	return &lakehouseTableMaintenanceResource{client: client}
+}
+
+// ValidateConfig checks that the job does something and that the retention period is valid.
+func (r *lakehouseTableMaintenanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var optimize, vOrder types.Bool
+	var zOrderBy types.List
+	var vacuumRetentionPeriod types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("optimize"), &optimize)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("v_order"), &vOrder)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("z_order_by"), &zOrderBy)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vacuum_retention_period"), &vacuumRetentionPeriod)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !optimize.IsNull() && !optimize.IsUnknown() && !optimize.ValueBool() {
+		if !vOrder.IsNull() || !zOrderBy.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("optimize"),
+				"Invalid optimize settings",
+				"v_order and z_order_by only apply when optimize is enabled.",
+			)
+		}
+		if vacuumRetentionPeriod.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("optimize"),
+				"Nothing to maintain",
+				"Enable optimize or set vacuum_retention_period.",
+			)
+		}
+	}
+
+	if !vacuumRetentionPeriod.IsNull() && !vacuumRetentionPeriod.IsUnknown() &&
+		!vacuumRetentionPeriodPattern.MatchString(vacuumRetentionPeriod.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("vacuum_retention_period"),
+			"Invalid vacuum retention period",
+			fmt.Sprintf("%q is not in the format 'd.hh:mm:ss', e.g. '7.01:00:00'.", vacuumRetentionPeriod.ValueString()),
+		)
+	}
+}
+
+// Create runs the maintenance job.
+func (r *lakehouseTableMaintenanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan lakehouseTableMaintenanceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.runMaintenance(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.WorkspaceID.ValueString(), plan.LakehouseID.ValueString(), plan.TableName.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded job instance; a completed job does not change.
+func (r *lakehouseTableMaintenanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state lakehouseTableMaintenanceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update runs the job again when a maintenance setting or the triggers changed.
+func (r *lakehouseTableMaintenanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan lakehouseTableMaintenanceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state lakehouseTableMaintenanceResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	if tableMaintenanceSettingsChanged(plan, state) {
+		diags = r.runMaintenance(&plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			// Keep the previous settings, so that the next apply runs the job again.
+			diags = resp.State.Set(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	} else {
+		plan.JobInstanceID = state.JobInstanceID
+		plan.Status = state.Status
+		plan.StartTime = state.StartTime
+		plan.EndTime = state.EndTime
+		plan.LastUpdated = state.LastUpdated
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the maintenance from the state. Maintenance that already ran cannot be undone.
+func (r *lakehouseTableMaintenanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+// runMaintenance runs the TableMaintenance job, waits for it and stores the job instance details in plan.
+func (r *lakehouseTableMaintenanceResource) runMaintenance(plan *lakehouseTableMaintenanceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instanceURL, err := r.client.RunOnDemandJob(plan.WorkspaceID.ValueString(), plan.LakehouseID.ValueString(), tableMaintenanceJobType, tableMaintenanceExecutionData(*plan))
+	if err != nil {
+		diags.AddError(
+			"Error running table maintenance",
+			fmt.Sprintf("Could not start the maintenance of table %s: %s", plan.TableName.ValueString(), err.Error()),
+		)
+		return diags
+	}
+
+	instance, err := r.client.WaitForJobInstance(instanceURL)
+	if err != nil {
+		diags.AddError(
+			"Error running table maintenance",
+			fmt.Sprintf("The maintenance of table %s did not complete: %s", plan.TableName.ValueString(), err.Error()),
+		)
+		return diags
+	}
+
+	jobInstanceID, _ := getMapString("id", instance)
+	status, _ := getMapString("status", instance)
+	startTime, _ := getMapString("startTimeUtc", instance)
+	endTime, _ := getMapString("endTimeUtc", instance)
+
+	plan.JobInstanceID = types.StringValue(jobInstanceID)
+	plan.Status = types.StringValue(status)
+	plan.StartTime = types.StringValue(startTime)
+	plan.EndTime = types.StringValue(endTime)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return diags
+}
+
+// tableMaintenanceExecutionData builds the execution data of a TableMaintenance job instance.
+func tableMaintenanceExecutionData(model lakehouseTableMaintenanceResourceModel) map[string]interface{} {
+	executionData := map[string]interface{}{
+		"tableName": model.TableName.ValueString(),
+	}
+	if !model.SchemaName.IsNull() {
+		executionData["schemaName"] = model.SchemaName.ValueString()
+	}
+
+	if model.Optimize.ValueBool() {
+		optimizeSettings := map[string]interface{}{}
+		if !model.VOrder.IsNull() {
+			optimizeSettings["vOrder"] = model.VOrder.ValueBool()
+		}
+		if len(model.ZOrderBy) > 0 {
+			columns := make([]string, len(model.ZOrderBy))
+			for i, column := range model.ZOrderBy {
+				columns[i] = column.ValueString()
+			}
+			optimizeSettings["zOrderBy"] = columns
+		}
+		executionData["optimizeSettings"] = optimizeSettings
+	}
+
+	if !model.VacuumRetentionPeriod.IsNull() {
+		executionData["vacuumSettings"] = map[string]interface{}{
+			"retentionPeriod": model.VacuumRetentionPeriod.ValueString(),
+		}
+	}
+
+	return executionData
+}
+
+// tableMaintenanceSettingsChanged reports whether the job has to run again.
+func tableMaintenanceSettingsChanged(plan, state lakehouseTableMaintenanceResourceModel) bool {
+	if !plan.Optimize.Equal(state.Optimize) || !plan.VOrder.Equal(state.VOrder) ||
+		!plan.VacuumRetentionPeriod.Equal(state.VacuumRetentionPeriod) || !plan.Triggers.Equal(state.Triggers) {
+		return true
+	}
+	return !equalStringValues(plan.ZOrderBy, state.ZOrderBy)
+}
+
+// stringValues converts a list of Terraform strings into Go strings.
+func stringValues(values []types.String) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = value.ValueString()
+	}
+	return result
+}
+
+// equalStringValues reports whether two lists of Terraform strings hold the same values in the same order.
+func equalStringValues(a, b []types.String) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -4,12 +4,18 @@ import (
     "context"
     "encoding/json"
     "fmt"
+    "sort"
+    "strconv"
+    "strings"
     "terraform-provider-microsoftfabric/internal/apiclient"
     "time"
-    
 
+    "github.com/hashicorp/terraform-plugin-framework/diag"
     "github.com/hashicorp/terraform-plugin-framework/resource"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
     "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,35 +24,40 @@ type lakehouseTableResource struct {
 }
 
 func (r *lakehouseTableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
     resp.Schema = schema.Schema{
         Attributes: map[string]schema.Attribute{
             "id": schema.StringAttribute{
                 Computed: true,
-                Description: "The unique identifier for the lakehouse table, generated by the system.",
+                Description: "The identifier of the table in the form '<workspace id>/<lakehouse id>/<table name>'.",
             },
             "workspace_id": schema.StringAttribute{
                 Required: true,
                 Description: "The ID of the workspace to which the lakehouse table belongs.",
+                PlanModifiers: requiresReplace,
             },
             "lakehouse_id": schema.StringAttribute{
                 Required: true,
-                Description: "The ID of the lakehouse to which the table belongs.",
+                Description: "The ID of the lakehouse to which the table belongs. Lakehouses with schemas enabled are not supported, because the Fabric tables API cannot load or list their tables.",
+                PlanModifiers: requiresReplace,
             },
             "table_name": schema.StringAttribute{
                 Required: true,
                 Description: "The name of the lakehouse table.",
+                PlanModifiers: requiresReplace,
             },
             "relative_path": schema.StringAttribute{
                 Required: true,
-                Description: "The relative path to the table in the filesystem.",
+                Description: "The relative path of the file or folder to load, e.g. 'Files/data/sales.csv'. Changing it loads the table again.",
             },
             "path_type": schema.StringAttribute{
                 Required: true,
-                Description: "The type of path used (absolute or relative).",
+                Description: "The type of the relative path: 'File' or 'Folder'.",
             },
             "mode": schema.StringAttribute{
                 Required: true,
-                Description: "The mode of the table operation (e.g., overwrite, append).",
+                Description: "The load mode: 'Overwrite' or 'Append'. Changing it loads the table again.",
             },
             "recursive": schema.BoolAttribute{
                 Optional: true,
@@ -58,6 +69,7 @@ func (r *lakehouseTableResource) Schema(_ context.Context, _ resource.SchemaRequ
             },
             "format_options": schema.SingleNestedAttribute{
                 Required: true,
+                Description: "The format of the loaded files. Changing it loads the table again.",
                 Attributes: map[string]schema.Attribute{
                     "format": schema.StringAttribute{
                         Required: true,
@@ -73,9 +85,31 @@ func (r *lakehouseTableResource) Schema(_ context.Context, _ resource.SchemaRequ
                     },
                 },
             },
+            "drop_on_destroy": schema.BoolAttribute{
+                Optional: true,
+                Computed: true,
+                Default: booldefault.StaticBool(false),
+                Description: "Whether destroying the resource drops the table by deleting its delta folder through OneLake. By default the table is kept.",
+            },
+            "operation_id": schema.StringAttribute{
+                Computed: true,
+                Description: "The ID of the long-running operation of the last load.",
+            },
+            "rows_written": schema.Int64Attribute{
+                Computed: true,
+                Description: "The number of rows written by the last load, read from the delta log.",
+            },
+            "files_written": schema.Int64Attribute{
+                Computed: true,
+                Description: "The number of parquet files written by the last load, read from the delta log.",
+            },
+            "bytes_written": schema.Int64Attribute{
+                Computed: true,
+                Description: "The number of bytes written by the last load, read from the delta log.",
+            },
             "last_updated": schema.StringAttribute{
                 Computed: true,
-                Description: "The timestamp of the last update made to the table.",
+                Description: "The timestamp of the last load of the table.",
             },
         },
     }
@@ -92,6 +126,11 @@ type lakehouseTableResourceModel struct {
     Recursive     types.Bool         `tfsdk:"recursive"`
     FileExtension types.String       `tfsdk:"file_extension"`
     FormatOptions formatOptionsModel `tfsdk:"format_options"`
+    DropOnDestroy types.Bool         `tfsdk:"drop_on_destroy"`
+    OperationID   types.String       `tfsdk:"operation_id"`
+    RowsWritten   types.Int64        `tfsdk:"rows_written"`
+    FilesWritten  types.Int64        `tfsdk:"files_written"`
+    BytesWritten  types.Int64        `tfsdk:"bytes_written"`
     LastUpdated   types.String       `tfsdk:"last_updated"`
 }
 
@@ -117,17 +156,13 @@ func (r *lakehouseTableResource) Create(ctx context.Context, req resource.Create
         return
     }
 
-    operationID, err := r.loadTable(plan)
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error loading table",
-            "Could not load table: "+err.Error(),
-        )
+    diags = r.loadTable(&plan)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
         return
     }
 
-    plan.ID = types.StringValue(operationID)
-    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    plan.ID = types.StringValue(lakehouseTableID(plan))
 
     diags = resp.State.Set(ctx, plan)
     resp.Diagnostics.Append(diags...)
@@ -145,57 +180,99 @@ func (r *lakehouseTableResource) Read(ctx context.Context, req resource.ReadRequ
     url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/lakehouses/%s/tables",
         state.WorkspaceID.ValueString(), state.LakehouseID.ValueString())
 
-    // Call the API to get table data
-    tableInfo, err := r.client.Get(url)
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error reading tables",
-            fmt.Sprintf("Could not read table data: %s", err),
-        )
-        return
-    }
-
-    tables := tableInfo // Use it directly as it is of type map[string]interface{}
+    // Tables are returned in pages, follow the continuation URI until the table is found.
+    found := false
+    for url != "" && !found {
+        tables, err := r.client.Get(url)
+        if err != nil {
+            if isNotFoundError(err) {
+                resp.State.RemoveResource(ctx)
+                return
+            }
+            resp.Diagnostics.AddError(
+                "Error reading tables",
+                fmt.Sprintf("Could not read table data: %s", err),
+            )
+            return
+        }
 
-    // Extract relevant data from the response
-    data, exists := tables["data"].([]interface{})
-    if !exists {
-        resp.Diagnostics.AddError("Error reading tables", "No 'data' field found in response.")
-        return
-    }
+        // Extract relevant data from the response
+        data, exists := tables["data"].([]interface{})
+        if !exists {
+            resp.Diagnostics.AddError("Error reading tables", "No 'data' field found in response.")
+            return
+        }
 
-    currentTableNames := make(map[string]struct{})
-    for _, item := range data {
-        if itemMap, valid := item.(map[string]interface{}); valid {
-            if name, exists := itemMap["name"].(string); exists {
-                currentTableNames[name] = struct{}{}
+        for _, item := range data {
+            if itemMap, valid := item.(map[string]interface{}); valid {
+                if name, exists := itemMap["name"].(string); exists && name == state.TableName.ValueString() {
+                    found = true
+                }
             }
         }
+
+        url, _ = getMapString("continuationUri", tables)
     }
 
     // Check if the table name in the state exists in the current tables
-    if state.TableName.ValueString() != "" {
-        if _, exists := currentTableNames[state.TableName.ValueString()]; !exists {
-            resp.Diagnostics.AddWarning(
-                "Table missing",
-                fmt.Sprintf("The table '%s' specified in the state does not exist in the lakehouse. Terraform will recreate it.", state.TableName.ValueString()),
-            )
-            // Remove the resource from state to force a recreation
-            resp.State.RemoveResource(ctx)
-            return
-        }
+    if !found {
+        resp.Diagnostics.AddWarning(
+            "Table missing",
+            fmt.Sprintf("The table '%s' specified in the state does not exist in the lakehouse. Terraform will recreate it.", state.TableName.ValueString()),
+        )
+        // Remove the resource from state to force a recreation
+        resp.State.RemoveResource(ctx)
+        return
     }
 
+    // Tables loaded by earlier versions have an ID of the form "Files/<table>".
+    state.ID = types.StringValue(lakehouseTableID(state))
+
     // Update the state
     diags = resp.State.Set(ctx, state)
     resp.Diagnostics.Append(diags...)
 }
 
-
+// Update loads the table again when the load settings changed. Changing only drop_on_destroy keeps the last load.
 func (r *lakehouseTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-    resp.Diagnostics.AddError("Error updating resource", "Update operation for lakehouse table is not supported.")
+    var plan lakehouseTableResourceModel
+    diags := req.Plan.Get(ctx, &plan)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    var state lakehouseTableResourceModel
+    diags = req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    plan.ID = state.ID
+    if lakehouseTableLoadChanged(plan, state) {
+        diags = r.loadTable(&plan)
+        resp.Diagnostics.Append(diags...)
+        if resp.Diagnostics.HasError() {
+            // Keep the previous load settings, so that the next apply runs the load again.
+            diags = resp.State.Set(ctx, state)
+            resp.Diagnostics.Append(diags...)
+            return
+        }
+    } else {
+        plan.OperationID = state.OperationID
+        plan.RowsWritten = state.RowsWritten
+        plan.FilesWritten = state.FilesWritten
+        plan.BytesWritten = state.BytesWritten
+        plan.LastUpdated = state.LastUpdated
+    }
+
+    diags = resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
 }
 
+// Delete drops the table when drop_on_destroy is set. Fabric has no API to delete a table, so its delta
+// folder is deleted through OneLake; otherwise the table is only removed from the state.
 func (r *lakehouseTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
     var state lakehouseTableResourceModel
     diags := req.State.Get(ctx, &state)
@@ -204,36 +281,183 @@ func (r *lakehouseTableResource) Delete(ctx context.Context, req resource.Delete
         return
     }
 
-    // Deletion logic might be added here, if needed
-    resp.State.RemoveResource(ctx)
+    if state.DropOnDestroy.ValueBool() {
+        err := r.client.DeleteOneLakePath(lakehouseTableFolder(state), true)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error dropping table",
+                fmt.Sprintf("Could not delete the delta folder of table %s: %s", state.TableName.ValueString(), err.Error()),
+            )
+            return
+        }
+    }
 
+    resp.State.RemoveResource(ctx)
 }
-func (r *lakehouseTableResource) loadTable(plan lakehouseTableResourceModel) (string, error) {
+
+// loadTable starts the load, waits for it to finish and stores the operation ID and the statistics in plan.
+func (r *lakehouseTableResource) loadTable(plan *lakehouseTableResourceModel) diag.Diagnostics {
+    var diags diag.Diagnostics
+
+    // The table folder of a lakehouse with schemas is Tables/<schema>/<table>, and Fabric rejects loading
+    // into it, so fail before starting the load instead of managing the wrong delta folder.
+    schemasEnabled, err := r.lakehouseSchemasEnabled(plan.WorkspaceID.ValueString(), plan.LakehouseID.ValueString())
+    if err != nil {
+        diags.AddError(
+            "Error loading table",
+            fmt.Sprintf("Could not read lakehouse %s: %s", plan.LakehouseID.ValueString(), err.Error()),
+        )
+        return diags
+    }
+    if schemasEnabled {
+        diags.AddError(
+            "Lakehouse with schemas not supported",
+            fmt.Sprintf("Lakehouse %s has schemas enabled. The Fabric tables API cannot load or list the tables of such lakehouses.", plan.LakehouseID.ValueString()),
+        )
+        return diags
+    }
+
     url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/lakehouses/%s/tables/%s/load",
         plan.WorkspaceID.ValueString(), plan.LakehouseID.ValueString(), plan.TableName.ValueString())
 
+    formatOptions := map[string]interface{}{
+        "format": plan.FormatOptions.Format.ValueString(),
+    }
+    if !plan.FormatOptions.Header.IsNull() {
+        formatOptions["header"] = plan.FormatOptions.Header.ValueBool()
+    }
+    if !plan.FormatOptions.Delimiter.IsNull() {
+        formatOptions["delimiter"] = plan.FormatOptions.Delimiter.ValueString()
+    }
+
     body := map[string]interface{}{
         "relativePath":  plan.RelativePath.ValueString(),
         "pathType":      plan.PathType.ValueString(),
         "mode":          plan.Mode.ValueString(),
         "recursive":     plan.Recursive.ValueBool(),
-        "fileExtension": plan.FileExtension.ValueString(),
-        "format":        plan.FormatOptions.Format.ValueString(),
-        "header":        plan.FormatOptions.Header.ValueBool(),
-        "delimiter":     plan.FormatOptions.Delimiter.ValueString(),
+        "formatOptions": formatOptions,
+    }
+    if !plan.FileExtension.IsNull() {
+        body["fileExtension"] = plan.FileExtension.ValueString()
     }
 
-    jsonBody, err := json.Marshal(body)
+    operationID, interval, err := r.client.StartLongRunningOperation(url, body)
     if err != nil {
-        return "", fmt.Errorf("failed to marshal body: %v", err)
+        diags.AddError(
+            "Error loading table",
+            fmt.Sprintf("Could not start loading table %s: %s", plan.TableName.ValueString(), err.Error()),
+        )
+        return diags
     }
 
-    _, err = r.client.PostBytes(url, jsonBody)
+    if _, err := r.client.WaitForOperation(operationID, interval); err != nil {
+        diags.AddError(
+            "Error loading table",
+            fmt.Sprintf("Loading table %s failed (operation %s): %s", plan.TableName.ValueString(), operationID, err.Error()),
+        )
+        return diags
+    }
+
+    plan.OperationID = types.StringValue(operationID)
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+    rows, files, bytes, err := r.readLoadStatistics(*plan)
     if err != nil {
-        return "", fmt.Errorf("failed to create table: %v", err)
+        plan.RowsWritten = types.Int64Null()
+        plan.FilesWritten = types.Int64Null()
+        plan.BytesWritten = types.Int64Null()
+        diags.AddWarning(
+            "Load statistics unavailable",
+            fmt.Sprintf("Table %s was loaded, but its statistics could not be read from the delta log: %s", plan.TableName.ValueString(), err.Error()),
+        )
+        return diags
     }
+    plan.RowsWritten = types.Int64Value(rows)
+    plan.FilesWritten = types.Int64Value(files)
+    plan.BytesWritten = types.Int64Value(bytes)
 
-    id := fmt.Sprintf("Files/%s", plan.TableName.ValueString())
+    return diags
+}
+
+// readLoadStatistics reads the operation metrics of the latest commit in the table's delta log.
+func (r *lakehouseTableResource) readLoadStatistics(table lakehouseTableResourceModel) (int64, int64, int64, error) {
+    paths, err := r.client.ListOneLakePaths(lakehouseTableFolder(table)+"/_delta_log", false)
+    if err != nil {
+        return 0, 0, 0, err
+    }
+
+    // Commit files are named by their zero-padded version, so the last one in order is the latest commit.
+    var commits []string
+    for _, path := range paths {
+        if !path.IsDirectory && strings.HasSuffix(path.Name, ".json") {
+            commits = append(commits, path.Name)
+        }
+    }
+    if len(commits) == 0 {
+        return 0, 0, 0, fmt.Errorf("no commits found")
+    }
+    sort.Strings(commits)
+
+    content, err := r.client.ReadOneLakeFile(table.WorkspaceID.ValueString() + "/" + commits[len(commits)-1])
+    if err != nil {
+        return 0, 0, 0, err
+    }
+
+    // A commit holds one JSON action per line; the commitInfo action carries the operation metrics.
+    for _, line := range strings.Split(string(content), "\n") {
+        var action struct {
+            CommitInfo *struct {
+                OperationMetrics map[string]string `json:"operationMetrics"`
+            } `json:"commitInfo"`
+        }
+        if err := json.Unmarshal([]byte(line), &action); err != nil || action.CommitInfo == nil {
+            continue
+        }
+
+        metrics := action.CommitInfo.OperationMetrics
+        rows, _ := strconv.ParseInt(metrics["numOutputRows"], 10, 64)
+        files, _ := strconv.ParseInt(metrics["numFiles"], 10, 64)
+        bytes, _ := strconv.ParseInt(metrics["numOutputBytes"], 10, 64)
+        return rows, files, bytes, nil
+    }
+
+    return 0, 0, 0, fmt.Errorf("no commit information found in %s", commits[len(commits)-1])
+}
+
+// lakehouseSchemasEnabled reports whether the lakehouse has schemas enabled; only those have a default schema.
+func (r *lakehouseTableResource) lakehouseSchemasEnabled(workspaceID, lakehouseID string) (bool, error) {
+    url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/lakehouses/%s", workspaceID, lakehouseID)
+    lakehouse, err := r.client.Get(url)
+    if err != nil {
+        return false, err
+    }
+
+    properties, ok := lakehouse["properties"].(map[string]interface{})
+    if !ok {
+        return false, nil
+    }
+    _, ok = getMapString("defaultSchema", properties)
+    return ok, nil
+}
+
+// lakehouseTableID returns the "<workspace id>/<lakehouse id>/<table name>" ID of a table.
+func lakehouseTableID(table lakehouseTableResourceModel) string {
+    return fmt.Sprintf("%s/%s/%s", table.WorkspaceID.ValueString(), table.LakehouseID.ValueString(), table.TableName.ValueString())
+}
+
+// lakehouseTableFolder returns the OneLake path of the table's delta folder.
+func lakehouseTableFolder(table lakehouseTableResourceModel) string {
+    return fmt.Sprintf("%s/%s/Tables/%s", table.WorkspaceID.ValueString(), table.LakehouseID.ValueString(), table.TableName.ValueString())
+}
 
-    return id, nil
+// lakehouseTableLoadChanged reports whether any setting of the load differs between plan and state.
+func lakehouseTableLoadChanged(plan, state lakehouseTableResourceModel) bool {
+    return !plan.RelativePath.Equal(state.RelativePath) ||
+        !plan.PathType.Equal(state.PathType) ||
+        !plan.Mode.Equal(state.Mode) ||
+        !plan.Recursive.Equal(state.Recursive) ||
+        !plan.FileExtension.Equal(state.FileExtension) ||
+        !plan.FormatOptions.Format.Equal(state.FormatOptions.Format) ||
+        !plan.FormatOptions.Header.Equal(state.FormatOptions.Header) ||
+        !plan.FormatOptions.Delimiter.Equal(state.FormatOptions.Delimiter)
 }
@@ -0,0 +1,534 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &oneLakeDataAccessRolesResource{}
+)
+
+// dataAccessRoleNamePattern matches the role names accepted by OneLake: letters and digits, starting with a letter.
+var dataAccessRoleNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]{0,127}$`)
+
+// validEntraMemberObjectTypes are the object types of Microsoft Entra members of a data access role.
+var validEntraMemberObjectTypes = []string{"User", "Group", "ServicePrincipal", "ManagedIdentity"}
+
+// validFabricItemAccess are the item permissions that make a principal a member of a data access role.
+var validFabricItemAccess = []string{"Read", "ReadAll", "Write", "Reshare", "Explore", "Execute"}
+
+// oneLakeDataAccessRolesResource manages the complete set of OneLake data access roles of an item.
+type oneLakeDataAccessRolesResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the OneLake data access roles resource.
+func (r *oneLakeDataAccessRolesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the OneLake data access roles (folder-level security) of an item such as a lakehouse. The roles are authoritative: every change replaces the whole role set in one request, so roles that are not declared here, including the DefaultReader role, are removed. OneLake data access roles must be enabled on the item.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the role set in the form '<workspace id>/<item id>'.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the item.",
+				PlanModifiers: requiresReplace,
+			},
+			"item_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the item, e.g. a lakehouse.",
+				PlanModifiers: requiresReplace,
+			},
+			"roles": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "The data access roles, keyed by role name. Role names contain only letters and digits and start with a letter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"paths": schema.SetAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Description: "The paths the role grants access to, e.g. 'Tables/sales' or 'Files/raw', or '*' for the whole item.",
+						},
+						"actions": schema.SetAttribute{
+							Optional:    true,
+							Computed:    true,
+							ElementType: types.StringType,
+							Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Read")})),
+							Description: "The actions the role permits on the paths. Defaults to ['Read'].",
+						},
+						"entra_members": schema.SetNestedAttribute{
+							Optional:    true,
+							Description: "The Microsoft Entra users, groups and service principals that are members of the role.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"tenant_id": schema.StringAttribute{
+										Required:    true,
+										Description: "The ID of the tenant of the principal.",
+									},
+									"object_id": schema.StringAttribute{
+										Required:    true,
+										Description: "The object ID of the principal.",
+									},
+									"object_type": schema.StringAttribute{
+										Required:    true,
+										Description: fmt.Sprintf("The type of the principal: %s.", quotedList(validEntraMemberObjectTypes)),
+									},
+								},
+							},
+						},
+						"fabric_item_members": schema.SetNestedAttribute{
+							Optional:    true,
+							Description: "Members by item permission: every principal with the given permissions on the source item is a member of the role.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"source_path": schema.StringAttribute{
+										Required:    true,
+										Description: "The item whose permissions are checked, in the form '<workspace id>/<item id>'.",
+									},
+									"item_access": schema.SetAttribute{
+										Required:    true,
+										ElementType: types.StringType,
+										Description: fmt.Sprintf("The item permissions a principal needs to be a member: %s.", quotedList(validFabricItemAccess)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update of the roles.",
+			},
+		},
+	}
+}
+
+// oneLakeDataAccessRolesResourceModel defines the model of the OneLake data access roles resource.
+type oneLakeDataAccessRolesResourceModel struct {
+	ID          types.String                          `tfsdk:"id"`
+	WorkspaceID types.String                          `tfsdk:"workspace_id"`
+	ItemID      types.String                          `tfsdk:"item_id"`
+	Roles       map[string]oneLakeDataAccessRoleModel `tfsdk:"roles"`
+	LastUpdated types.String                          `tfsdk:"last_updated"`
+}
+
+// oneLakeDataAccessRoleModel defines one data access role.
+type oneLakeDataAccessRoleModel struct {
+	Paths             []types.String                 `tfsdk:"paths"`
+	Actions           []types.String                 `tfsdk:"actions"`
+	EntraMembers      []oneLakeEntraMemberModel      `tfsdk:"entra_members"`
+	FabricItemMembers []oneLakeFabricItemMemberModel `tfsdk:"fabric_item_members"`
+}
+
+// oneLakeEntraMemberModel defines a Microsoft Entra member of a data access role.
+type oneLakeEntraMemberModel struct {
+	TenantID   types.String `tfsdk:"tenant_id"`
+	ObjectID   types.String `tfsdk:"object_id"`
+	ObjectType types.String `tfsdk:"object_type"`
+}
+
+// oneLakeFabricItemMemberModel defines the members of a data access role that hold permissions on an item.
+type oneLakeFabricItemMemberModel struct {
+	SourcePath types.String   `tfsdk:"source_path"`
+	ItemAccess []types.String `tfsdk:"item_access"`
+}
+
+// Metadata sets the resource type name.
+func (r *oneLakeDataAccessRolesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_onelake_data_access_roles"
+}
+
+// NewOneLakeDataAccessRolesResource creates a new OneLake data access roles resource.
+func NewOneLakeDataAccessRolesResource(client *apiclient.APIClient) resource.Resource {
+	return &oneLakeDataAccessRolesResource{client: client}
+}
+
+// ValidateConfig checks role names, paths and member values before anything is sent to Fabric.
+func (r *oneLakeDataAccessRolesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var roles types.Map
+	diags := req.Config.GetAttribute(ctx, path.Root("roles"), &roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || roles.IsNull() || roles.IsUnknown() {
+		return
+	}
+
+	for name, value := range roles.Elements() {
+		rolePath := path.Root("roles").AtMapKey(name)
+		if !dataAccessRoleNamePattern.MatchString(name) {
+			resp.Diagnostics.AddAttributeError(
+				rolePath,
+				"Invalid role name",
+				fmt.Sprintf("Role name %q must start with a letter and contain only letters and digits, up to 128 characters.", name),
+			)
+		}
+
+		role, ok := value.(types.Object)
+		if !ok || role.IsNull() || role.IsUnknown() {
+			continue
+		}
+		attributes := role.Attributes()
+
+		if paths, ok := attributes["paths"].(types.Set); ok && !paths.IsUnknown() {
+			for _, element := range paths.Elements() {
+				rolePathValue, ok := element.(types.String)
+				if !ok || rolePathValue.IsUnknown() {
+					continue
+				}
+				if !isValidDataAccessPath(rolePathValue.ValueString()) {
+					resp.Diagnostics.AddAttributeError(
+						rolePath.AtName("paths"),
+						"Invalid role path",
+						fmt.Sprintf("Path %q must be '*' or start with 'Tables/' or 'Files/'.", rolePathValue.ValueString()),
+					)
+				}
+			}
+		}
+
+		if members, ok := attributes["entra_members"].(types.Set); ok && !members.IsUnknown() {
+			for _, element := range members.Elements() {
+				member, ok := element.(types.Object)
+				if !ok || member.IsUnknown() {
+					continue
+				}
+				objectType, ok := member.Attributes()["object_type"].(types.String)
+				if ok && !objectType.IsUnknown() && !containsString(validEntraMemberObjectTypes, objectType.ValueString()) {
+					resp.Diagnostics.AddAttributeError(
+						rolePath.AtName("entra_members"),
+						"Invalid member object type",
+						fmt.Sprintf("Object type %q must be one of %s.", objectType.ValueString(), quotedList(validEntraMemberObjectTypes)),
+					)
+				}
+			}
+		}
+
+		if members, ok := attributes["fabric_item_members"].(types.Set); ok && !members.IsUnknown() {
+			for _, element := range members.Elements() {
+				member, ok := element.(types.Object)
+				if !ok || member.IsUnknown() {
+					continue
+				}
+				itemAccess, ok := member.Attributes()["item_access"].(types.Set)
+				if !ok || itemAccess.IsUnknown() {
+					continue
+				}
+				for _, accessElement := range itemAccess.Elements() {
+					access, ok := accessElement.(types.String)
+					if ok && !access.IsUnknown() && !containsString(validFabricItemAccess, access.ValueString()) {
+						resp.Diagnostics.AddAttributeError(
+							rolePath.AtName("fabric_item_members"),
+							"Invalid item access",
+							fmt.Sprintf("Item access %q must be one of %s.", access.ValueString(), quotedList(validFabricItemAccess)),
+						)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Create replaces the roles of the item with the declared roles.
+func (r *oneLakeDataAccessRolesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oneLakeDataAccessRolesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ReplaceDataAccessRoles(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), dataAccessRolesBody(plan.Roles))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating data access roles",
+			fmt.Sprintf("Could not set the data access roles of item %s: %s", plan.ItemID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.WorkspaceID.ValueString() + "/" + plan.ItemID.ValueString())
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes all roles of the item, so that roles added or changed outside of Terraform show up in the plan.
+func (r *oneLakeDataAccessRolesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneLakeDataAccessRolesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := r.client.ListDataAccessRoles(state.WorkspaceID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading data access roles",
+			fmt.Sprintf("Could not list the data access roles of item %s: %s", state.ItemID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Roles = dataAccessRolesFromResponse(roles)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update replaces the roles of the item with the declared roles in one request.
+func (r *oneLakeDataAccessRolesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan oneLakeDataAccessRolesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state oneLakeDataAccessRolesResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ReplaceDataAccessRoles(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), dataAccessRolesBody(plan.Roles))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating data access roles",
+			fmt.Sprintf("Could not set the data access roles of item %s: %s", plan.ItemID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes all data access roles of the item.
+func (r *oneLakeDataAccessRolesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneLakeDataAccessRolesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.ReplaceDataAccessRoles(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), nil)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting data access roles",
+			fmt.Sprintf("Could not remove the data access roles of item %s: %s", state.ItemID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// dataAccessRolesBody builds the role set sent to Fabric, sorted by role name so requests are stable.
+func dataAccessRolesBody(roles map[string]oneLakeDataAccessRoleModel) []map[string]interface{} {
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	body := make([]map[string]interface{}, 0, len(roles))
+	for _, name := range names {
+		role := roles[name]
+
+		paths := make([]string, len(role.Paths))
+		for i, rolePath := range role.Paths {
+			paths[i] = dataAccessPathToAPI(rolePath.ValueString())
+		}
+
+		entraMembers := make([]map[string]interface{}, 0, len(role.EntraMembers))
+		for _, member := range role.EntraMembers {
+			entraMembers = append(entraMembers, map[string]interface{}{
+				"tenantId":   member.TenantID.ValueString(),
+				"objectId":   member.ObjectID.ValueString(),
+				"objectType": member.ObjectType.ValueString(),
+			})
+		}
+
+		fabricItemMembers := make([]map[string]interface{}, 0, len(role.FabricItemMembers))
+		for _, member := range role.FabricItemMembers {
+			fabricItemMembers = append(fabricItemMembers, map[string]interface{}{
+				"sourcePath": member.SourcePath.ValueString(),
+				"itemAccess": stringValues(member.ItemAccess),
+			})
+		}
+
+		body = append(body, map[string]interface{}{
+			"name": name,
+			"decisionRules": []map[string]interface{}{
+				{
+					"effect": "Permit",
+					"permission": []map[string]interface{}{
+						{"attributeName": "Path", "attributeValueIncludedIn": paths},
+						{"attributeName": "Action", "attributeValueIncludedIn": stringValues(role.Actions)},
+					},
+				},
+			},
+			"members": map[string]interface{}{
+				"microsoftEntraMembers": entraMembers,
+				"fabricItemMembers":     fabricItemMembers,
+			},
+		})
+	}
+
+	return body
+}
+
+// dataAccessRolesFromResponse converts the roles returned by Fabric into the model. The paths and actions of
+// all permit rules of a role are merged, since roles created in the portal may split them across rules.
+func dataAccessRolesFromResponse(roles []map[string]interface{}) map[string]oneLakeDataAccessRoleModel {
+	result := make(map[string]oneLakeDataAccessRoleModel, len(roles))
+
+	for _, role := range roles {
+		name, ok := getMapString("name", role)
+		if !ok {
+			continue
+		}
+		model := oneLakeDataAccessRoleModel{}
+
+		rules, _ := role["decisionRules"].([]interface{})
+		for _, ruleValue := range rules {
+			rule, ok := ruleValue.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if effect, _ := getMapString("effect", rule); effect != "" && effect != "Permit" {
+				continue
+			}
+			permissions, _ := rule["permission"].([]interface{})
+			for _, permissionValue := range permissions {
+				permission, ok := permissionValue.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				attributeName, _ := getMapString("attributeName", permission)
+				values, _ := permission["attributeValueIncludedIn"].([]interface{})
+				for _, value := range values {
+					text, ok := value.(string)
+					if !ok {
+						continue
+					}
+					switch attributeName {
+					case "Path":
+						model.Paths = append(model.Paths, types.StringValue(dataAccessPathFromAPI(text)))
+					case "Action":
+						model.Actions = append(model.Actions, types.StringValue(text))
+					}
+				}
+			}
+		}
+
+		members, _ := role["members"].(map[string]interface{})
+		entraMembers, _ := members["microsoftEntraMembers"].([]interface{})
+		for _, memberValue := range entraMembers {
+			member, ok := memberValue.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			tenantID, _ := getMapString("tenantId", member)
+			objectID, _ := getMapString("objectId", member)
+			objectType, _ := getMapString("objectType", member)
+			model.EntraMembers = append(model.EntraMembers, oneLakeEntraMemberModel{
+				TenantID:   types.StringValue(tenantID),
+				ObjectID:   types.StringValue(objectID),
+				ObjectType: types.StringValue(objectType),
+			})
+		}
+
+		fabricItemMembers, _ := members["fabricItemMembers"].([]interface{})
+		for _, memberValue := range fabricItemMembers {
+			member, ok := memberValue.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sourcePath, _ := getMapString("sourcePath", member)
+			itemMember := oneLakeFabricItemMemberModel{SourcePath: types.StringValue(sourcePath)}
+			itemAccess, _ := member["itemAccess"].([]interface{})
+			for _, access := range itemAccess {
+				if text, ok := access.(string); ok {
+					itemMember.ItemAccess = append(itemMember.ItemAccess, types.StringValue(text))
+				}
+			}
+			model.FabricItemMembers = append(model.FabricItemMembers, itemMember)
+		}
+
+		result[name] = model
+	}
+
+	return result
+}
+
+// isValidDataAccessPath reports whether a role path is '*' or points into the Tables or Files section.
+func isValidDataAccessPath(rolePath string) bool {
+	return rolePath == "*" || strings.HasPrefix(rolePath, "Tables/") || strings.HasPrefix(rolePath, "Files/")
+}
+
+// dataAccessPathToAPI converts a path such as "Tables/sales" into the rooted form "/Tables/sales" used by Fabric.
+func dataAccessPathToAPI(rolePath string) string {
+	if rolePath == "*" {
+		return rolePath
+	}
+	return "/" + strings.Trim(rolePath, "/")
+}
+
+// dataAccessPathFromAPI converts a rooted path returned by Fabric back into the form used in the configuration.
+func dataAccessPathFromAPI(rolePath string) string {
+	if rolePath == "*" {
+		return rolePath
+	}
+	return strings.Trim(rolePath, "/")
+}
+
+// containsString reports whether value is one of values.
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// quotedList formats values as a comma separated list of quoted strings for messages and descriptions.
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
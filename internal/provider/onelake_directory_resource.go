@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// oneLakeDirectoryResource creates a directory in an item, e.g. in the Files section of a lakehouse, through the OneLake DFS endpoint.
+type oneLakeDirectoryResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the OneLake directory resource.
+func (r *oneLakeDirectoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	useStateForUnknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
+	resp.Schema = schema.Schema{
+		Description: "Creates a directory in a Fabric item, e.g. in the Files section of a lakehouse, through the OneLake DFS endpoint. The directory is created without ACLs, so it inherits the permissions of the item.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The OneLake path of the directory in the form '<workspace id>/<item id>/<path>'.",
+				PlanModifiers: useStateForUnknown,
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the item.",
+				PlanModifiers: requiresReplace,
+			},
+			"item_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the item, e.g. a lakehouse.",
+				PlanModifiers: requiresReplace,
+			},
+			"path": schema.StringAttribute{
+				Required:      true,
+				Description:   "The path of the directory within the item, e.g. 'Files/reference'. Missing parent directories are created.",
+				PlanModifiers: requiresReplace,
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether to delete the directory together with its content on destroy. Otherwise destroying a directory that is not empty fails. Defaults to false.",
+			},
+			"url": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The DFS URL of the directory.",
+				PlanModifiers: useStateForUnknown,
+			},
+			"abfss_path": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The abfss:// URI of the directory, as used by Spark.",
+				PlanModifiers: useStateForUnknown,
+			},
+		},
+	}
+}
+
+// oneLakeDirectoryResourceModel defines the model of the OneLake directory resource.
+type oneLakeDirectoryResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	WorkspaceID  types.String `tfsdk:"workspace_id"`
+	ItemID       types.String `tfsdk:"item_id"`
+	Path         types.String `tfsdk:"path"`
+	ForceDestroy types.Bool   `tfsdk:"force_destroy"`
+	URL          types.String `tfsdk:"url"`
+	ABFSSPath    types.String `tfsdk:"abfss_path"`
+}
+
+// Metadata sets the resource type name.
+func (r *oneLakeDirectoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_onelake_directory"
+}
+
+// NewOneLakeDirectoryResource creates a new OneLake directory resource.
+func NewOneLakeDirectoryResource(client *apiclient.APIClient) resource.Resource {
+	return &oneLakeDirectoryResource{client: client}
+}
+
+// Create creates the directory.
+func (r *oneLakeDirectoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oneLakeDirectoryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oneLakePath := oneLakeItemPath(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), plan.Path.ValueString())
+	if err := r.client.CreateOneLakeDirectory(oneLakePath); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating directory",
+			fmt.Sprintf("Could not create %s: %s", plan.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(oneLakePath)
+	plan.URL = types.StringValue(apiclient.OneLakeURL(oneLakePath))
+	plan.ABFSSPath = types.StringValue(apiclient.OneLakeABFSSURL(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), strings.Trim(plan.Path.ValueString(), "/")))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read removes the directory from the state when it no longer exists.
+func (r *oneLakeDirectoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneLakeDirectoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	properties, err := r.client.GetOneLakePathProperties(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading directory",
+			fmt.Sprintf("Could not read the properties of %s: %s", state.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+	if properties == nil || !properties.IsDirectory {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes force_destroy, which is not stored in OneLake.
+func (r *oneLakeDirectoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan oneLakeDirectoryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the directory, and its content when force_destroy is set.
+func (r *oneLakeDirectoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneLakeDirectoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteOneLakePath(state.ID.ValueString(), state.ForceDestroy.ValueBool()); err != nil {
+		detail := fmt.Sprintf("Could not delete %s: %s", state.Path.ValueString(), err.Error())
+		if strings.Contains(err.Error(), "DirectoryNotEmpty") {
+			detail += "\n\nThe directory is not empty. Set force_destroy to delete it together with its content."
+		}
+		resp.Diagnostics.AddError("Error deleting directory", detail)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &oneLakeFileResource{}
+	_ resource.ResourceWithModifyPlan     = &oneLakeFileResource{}
+)
+
+// oneLakeFileResource uploads a file into an item, e.g. the Files section of a lakehouse, through the OneLake DFS endpoint.
+type oneLakeFileResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the OneLake file resource.
+func (r *oneLakeFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	useStateForUnknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
+	resp.Schema = schema.Schema{
+		Description: "Uploads a file into a Fabric item, e.g. a reference CSV into the Files section of a lakehouse, through the OneLake DFS endpoint. Large files are uploaded in chunks. Changes made outside of Terraform are detected by the MD5 of the content.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The OneLake path of the file in the form '<workspace id>/<item id>/<path>'.",
+				PlanModifiers: useStateForUnknown,
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the item.",
+				PlanModifiers: requiresReplace,
+			},
+			"item_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the item, e.g. a lakehouse.",
+				PlanModifiers: requiresReplace,
+			},
+			"path": schema.StringAttribute{
+				Required:      true,
+				Description:   "The path of the file within the item, e.g. 'Files/reference/countries.csv'. Missing directories are created.",
+				PlanModifiers: requiresReplace,
+			},
+			"content": schema.StringAttribute{
+				Optional:    true,
+				Description: "The content of the file. Conflicts with source.",
+			},
+			"source": schema.StringAttribute{
+				Optional:    true,
+				Description: "The local path of a file to upload. Conflicts with content.",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "The hex encoded MD5 of the content, as returned by filemd5().",
+			},
+			"content_length": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The size of the file in bytes.",
+			},
+			"url": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The DFS URL of the file.",
+				PlanModifiers: useStateForUnknown,
+			},
+			"abfss_path": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The abfss:// URI of the file, as used by Spark.",
+				PlanModifiers: useStateForUnknown,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last upload.",
+			},
+		},
+	}
+}
+
+// oneLakeFileResourceModel defines the model of the OneLake file resource.
+type oneLakeFileResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	WorkspaceID   types.String `tfsdk:"workspace_id"`
+	ItemID        types.String `tfsdk:"item_id"`
+	Path          types.String `tfsdk:"path"`
+	Content       types.String `tfsdk:"content"`
+	Source        types.String `tfsdk:"source"`
+	ContentMD5    types.String `tfsdk:"content_md5"`
+	ContentLength types.Int64  `tfsdk:"content_length"`
+	URL           types.String `tfsdk:"url"`
+	ABFSSPath     types.String `tfsdk:"abfss_path"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+}
+
+// Metadata sets the resource type name.
+func (r *oneLakeFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_onelake_file"
+}
+
+// NewOneLakeFileResource creates a new OneLake file resource.
+func NewOneLakeFileResource(client *apiclient.APIClient) resource.Resource {
+	return &oneLakeFileResource{client: client}
+}
+
+// ValidateConfig checks that exactly one of content and source is set.
+func (r *oneLakeFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var content, source types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &source)...)
+	if resp.Diagnostics.HasError() || content.IsUnknown() || source.IsUnknown() {
+		return
+	}
+
+	if content.IsNull() == source.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid file content",
+			"Exactly one of content and source must be set.",
+		)
+	}
+}
+
+// ModifyPlan hashes the local content so that changes show up in the plan, and so that a file changed
+// outside of Terraform is uploaded again.
+func (r *oneLakeFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan oneLakeFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Content.IsUnknown() || plan.Source.IsUnknown() {
+		return
+	}
+
+	content, err := oneLakeFileContent(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading file content",
+			err.Error(),
+		)
+		return
+	}
+	plan.ContentMD5 = types.StringValue(md5Hex(content))
+	plan.ContentLength = types.Int64Value(int64(len(content)))
+
+	// Keep the timestamp of the last upload when the content did not change; otherwise the file is
+	// uploaded again and gets a new timestamp.
+	if !req.State.Raw.IsNull() {
+		var state oneLakeFileResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.ContentMD5.Equal(state.ContentMD5) {
+			plan.LastUpdated = state.LastUpdated
+		} else {
+			plan.LastUpdated = types.StringUnknown()
+		}
+	}
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create uploads the file.
+func (r *oneLakeFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oneLakeFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.uploadFile(&plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading file",
+			fmt.Sprintf("Could not upload %s: %s", plan.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the MD5 and size of the file, so that changes made outside of Terraform show up in the plan.
+func (r *oneLakeFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oneLakeFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oneLakePath := oneLakeItemPath(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), state.Path.ValueString())
+	properties, err := r.client.GetOneLakePathProperties(oneLakePath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading file",
+			fmt.Sprintf("Could not read the properties of %s: %s", state.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+	if properties == nil || properties.IsDirectory {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	remoteMD5, err := r.remoteMD5(oneLakePath, properties)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading file",
+			fmt.Sprintf("Could not determine the MD5 of %s: %s", state.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+	state.ContentMD5 = types.StringValue(remoteMD5)
+	state.ContentLength = types.Int64Value(properties.ContentLength)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update uploads the file again when its content changed.
+func (r *oneLakeFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan oneLakeFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state oneLakeFileResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Switching between content and source with the same bytes does not need an upload.
+	if !plan.ContentMD5.IsUnknown() && plan.ContentMD5.Equal(state.ContentMD5) {
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	if err := r.uploadFile(&plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading file",
+			fmt.Sprintf("Could not upload %s: %s", plan.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the file.
+func (r *oneLakeFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oneLakeFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteOneLakePath(oneLakeItemPath(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), state.Path.ValueString()), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting file",
+			fmt.Sprintf("Could not delete %s: %s", state.Path.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// uploadFile uploads the content of the model and sets the computed attributes.
+func (r *oneLakeFileResource) uploadFile(model *oneLakeFileResourceModel) error {
+	content, err := oneLakeFileContent(*model)
+	if err != nil {
+		return err
+	}
+
+	oneLakePath := oneLakeItemPath(model.WorkspaceID.ValueString(), model.ItemID.ValueString(), model.Path.ValueString())
+	if err := r.client.UploadOneLakeFile(oneLakePath, content); err != nil {
+		return err
+	}
+
+	model.ID = types.StringValue(oneLakePath)
+	model.ContentMD5 = types.StringValue(md5Hex(content))
+	model.ContentLength = types.Int64Value(int64(len(content)))
+	model.URL = types.StringValue(apiclient.OneLakeURL(oneLakePath))
+	model.ABFSSPath = types.StringValue(apiclient.OneLakeABFSSURL(model.WorkspaceID.ValueString(), model.ItemID.ValueString(), strings.Trim(model.Path.ValueString(), "/")))
+	model.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	return nil
+}
+
+// remoteMD5 returns the hex encoded MD5 of a file. Files written without a stored MD5, e.g. by Spark,
+// are downloaded and hashed.
+func (r *oneLakeFileResource) remoteMD5(oneLakePath string, properties *apiclient.OneLakePathProperties) (string, error) {
+	if properties.ContentMD5 != "" {
+		checksum, err := base64.StdEncoding.DecodeString(properties.ContentMD5)
+		if err == nil {
+			return hex.EncodeToString(checksum), nil
+		}
+	}
+
+	content, err := r.client.ReadOneLakeFile(oneLakePath)
+	if err != nil {
+		return "", err
+	}
+	return md5Hex(content), nil
+}
+
+// oneLakeFileContent returns the configured content, or the content of the local source file.
+func oneLakeFileContent(model oneLakeFileResourceModel) ([]byte, error) {
+	if !model.Source.IsNull() {
+		content, err := os.ReadFile(model.Source.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("could not read source file %s: %v", model.Source.ValueString(), err)
+		}
+		return content, nil
+	}
+	return []byte(model.Content.ValueString()), nil
+}
+
+// oneLakeItemPath returns the OneLake path "<workspace id>/<item id>/<path>" of a path within an item.
+func oneLakeItemPath(workspaceID, itemID, itemPath string) string {
+	return fmt.Sprintf("%s/%s/%s", workspaceID, itemID, strings.Trim(itemPath, "/"))
+}
+
+// md5Hex returns the hex encoded MD5 of the content.
+func md5Hex(content []byte) string {
+	checksum := md5.Sum(content)
+	return hex.EncodeToString(checksum[:])
+}
@@ -0,0 +1,406 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &pipelineDeploymentResource{}
+)
+
+// pipelineDeploymentPollInterval is the time between two checks of a deployment operation.
+const pipelineDeploymentPollInterval = 5 * time.Second
+
+// pipelineDeploymentItemResultType is the object type of a per-item deployment result.
+var pipelineDeploymentItemResultType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"type":         types.StringType,
+		"status":       types.StringType,
+		"source_id":    types.StringType,
+		"target_id":    types.StringType,
+		"display_name": types.StringType,
+	},
+}
+
+// pipelineDeploymentResource deploys content from one deployment pipeline stage to another.
+// Every run is a new resource instance: changing any argument, including triggers, deploys again.
+// Stages are addressed by their Fabric IDs, so the deployment works with pipelines that have custom stages.
+type pipelineDeploymentResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the pipeline deployment resource.
+func (r *pipelineDeploymentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplaceString := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	requiresReplaceBool := []planmodifier.Bool{boolplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Deploys content between two stages of a deployment pipeline through the Fabric deploymentPipelines API. Fabric always creates missing items and overwrites existing items in the target stage and has no app update option, so the allowOverwriteArtifact, allowCreateArtifact and updateAppSettings options of the Power BI pipelines API are not available.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the deployment operation.",
+			},
+			"pipeline_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the deployment pipeline.",
+				PlanModifiers: requiresReplaceString,
+			},
+			"source_stage_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The Fabric ID of the stage to deploy from, e.g. from the stages of the pipeline resource.",
+				PlanModifiers: requiresReplaceString,
+			},
+			"target_stage_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The Fabric ID of the stage to deploy to.",
+				PlanModifiers: requiresReplaceString,
+			},
+			"items": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Items to deploy. When omitted, all items of the source stage are deployed.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"item_id": schema.StringAttribute{
+							Required:    true,
+							Description: "The ID of the item in the source stage.",
+						},
+						"item_type": schema.StringAttribute{
+							Required:    true,
+							Description: "The Fabric type of the item, e.g. 'Report', 'SemanticModel', 'Lakehouse' or 'Notebook'.",
+						},
+					},
+				},
+			},
+			"allow_cross_region_deployment": schema.BoolAttribute{
+				Optional:      true,
+				Description:   "Allow deploying to a stage whose workspace is in a different region.",
+				PlanModifiers: requiresReplaceBool,
+			},
+			"note": schema.StringAttribute{
+				Optional:      true,
+				Description:   "A note describing the deployment, shown in the deployment history.",
+				PlanModifiers: requiresReplaceString,
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that cause a new deployment when they change, e.g. a commit hash.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The final status of the deployment operation.",
+			},
+			"item_results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The result of every deployment step.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the step.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the step.",
+						},
+						"source_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the item in the source stage.",
+						},
+						"target_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the item in the target stage.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the item.",
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the deployment.",
+			},
+		},
+	}
+}
+
+// pipelineDeploymentResourceModel defines the model of the pipeline deployment resource.
+type pipelineDeploymentResourceModel struct {
+	ID                         types.String              `tfsdk:"id"`
+	PipelineID                 types.String              `tfsdk:"pipeline_id"`
+	SourceStageID              types.String              `tfsdk:"source_stage_id"`
+	TargetStageID              types.String              `tfsdk:"target_stage_id"`
+	Items                      []pipelineDeployItemModel `tfsdk:"items"`
+	AllowCrossRegionDeployment types.Bool                `tfsdk:"allow_cross_region_deployment"`
+	Note                       types.String              `tfsdk:"note"`
+	Triggers                   types.Map                 `tfsdk:"triggers"`
+	Status                     types.String              `tfsdk:"status"`
+	ItemResults                types.List                `tfsdk:"item_results"`
+	LastUpdated                types.String              `tfsdk:"last_updated"`
+}
+
+type pipelineDeployItemModel struct {
+	ItemID   types.String `tfsdk:"item_id"`
+	ItemType types.String `tfsdk:"item_type"`
+}
+
+// pipelineDeploymentItemResultModel is the result of one deployment step.
+type pipelineDeploymentItemResultModel struct {
+	Type        types.String `tfsdk:"type"`
+	Status      types.String `tfsdk:"status"`
+	SourceID    types.String `tfsdk:"source_id"`
+	TargetID    types.String `tfsdk:"target_id"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+// Metadata sets the resource type name.
+func (r *pipelineDeploymentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_pipeline_deployment"
+}
+
+// NewPipelineDeploymentResource creates a new pipeline deployment resource.
+func NewPipelineDeploymentResource(client *apiclient.APIClient) resource.Resource {
+	return &pipelineDeploymentResource{client: client}
+}
+
+// ValidateConfig checks that the deployment goes to another stage.
+func (r *pipelineDeploymentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config pipelineDeploymentResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.SourceStageID.IsUnknown() || config.TargetStageID.IsUnknown() {
+		return
+	}
+	if strings.EqualFold(config.SourceStageID.ValueString(), config.TargetStageID.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("target_stage_id"),
+			"Invalid target stage",
+			"The target stage must be different from the source stage.",
+		)
+	}
+}
+
+// Create runs the deployment and waits for it to finish.
+func (r *pipelineDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan pipelineDeploymentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	operationID, err := r.startDeployment(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deploying pipeline stage",
+			"Could not start the deployment: "+err.Error(),
+		)
+		return
+	}
+
+	operation, err := r.waitForDeployment(ctx, plan.PipelineID.ValueString(), operationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deploying pipeline stage",
+			fmt.Sprintf("Deployment operation %s did not finish: %s", operationID, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(operationID)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	status, failures := setPipelineDeploymentResult(&plan, operation)
+	plan.ItemResults, diags = types.ListValueFrom(ctx, pipelineDeploymentItemResultType, pipelineDeploymentItemResults(operation))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if status != "Succeeded" {
+		resp.Diagnostics.AddError(
+			"Error deploying pipeline stage",
+			fmt.Sprintf("Deployment operation %s finished with status %s.%s", operationID, status, failures),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded deployment; a deployment cannot drift.
+func (r *pipelineDeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state pipelineDeploymentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called because every argument requires replacement.
+func (r *pipelineDeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan pipelineDeploymentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the deployment from the state; deployed content stays in the target stage.
+func (r *pipelineDeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+// startDeployment starts the deployment of the source stage, or of the listed items, and returns the operation ID.
+func (r *pipelineDeploymentResource) startDeployment(model pipelineDeploymentResourceModel) (string, error) {
+	body := map[string]interface{}{
+		"sourceStageId": model.SourceStageID.ValueString(),
+		"targetStageId": model.TargetStageID.ValueString(),
+	}
+	if !model.AllowCrossRegionDeployment.IsNull() {
+		body["options"] = map[string]interface{}{
+			"allowCrossRegionDeployment": model.AllowCrossRegionDeployment.ValueBool(),
+		}
+	}
+	if !model.Note.IsNull() {
+		body["note"] = model.Note.ValueString()
+	}
+	if len(model.Items) > 0 {
+		items := make([]map[string]interface{}, 0, len(model.Items))
+		for _, item := range model.Items {
+			items = append(items, map[string]interface{}{
+				"sourceItemId": item.ItemID.ValueString(),
+				"itemType":     item.ItemType.ValueString(),
+			})
+		}
+		body["items"] = items
+	}
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/deploy", model.PipelineID.ValueString())
+	operationID, _, err := r.client.StartLongRunningOperation(url, body)
+	if err != nil {
+		return "", err
+	}
+
+	return operationID, nil
+}
+
+// waitForDeployment polls the pipeline operation until it is no longer running.
+func (r *pipelineDeploymentResource) waitForDeployment(ctx context.Context, pipelineID, operationID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/operations/%s", pipelineID, operationID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pipelineDeploymentPollInterval):
+		}
+
+		operation, err := r.client.Get(url)
+		if err != nil {
+			return nil, err
+		}
+
+		status, _ := operation["status"].(string)
+		switch status {
+		case "Succeeded", "Failed":
+			return operation, nil
+		}
+	}
+}
+
+// setPipelineDeploymentResult copies the operation status into the model and returns the status and
+// a description of the failed steps.
+func setPipelineDeploymentResult(model *pipelineDeploymentResourceModel, operation map[string]interface{}) (string, string) {
+	status, _ := operation["status"].(string)
+	model.Status = types.StringValue(status)
+
+	var failures []string
+	for _, step := range pipelineDeploymentSteps(operation) {
+		stepStatus, _ := step["status"].(string)
+		if stepStatus != "Failed" {
+			continue
+		}
+		sourceAndTarget, _ := step["sourceAndTarget"].(map[string]interface{})
+		displayName, _ := getMapString("sourceItemDisplayName", sourceAndTarget)
+		stepError, _ := step["error"].(map[string]interface{})
+		errorCode, _ := getMapString("errorCode", stepError)
+		failures = append(failures, fmt.Sprintf("%s (%s)", displayName, errorCode))
+	}
+
+	if len(failures) == 0 {
+		return status, ""
+	}
+	return status, " Failed items: " + strings.Join(failures, ", ")
+}
+
+// pipelineDeploymentItemResults converts the execution steps of an operation into item results.
+func pipelineDeploymentItemResults(operation map[string]interface{}) []pipelineDeploymentItemResultModel {
+	steps := pipelineDeploymentSteps(operation)
+	results := make([]pipelineDeploymentItemResultModel, 0, len(steps))
+	for _, step := range steps {
+		sourceAndTarget, _ := step["sourceAndTarget"].(map[string]interface{})
+		stepType, _ := getMapString("type", step)
+		stepStatus, _ := getMapString("status", step)
+		sourceID, _ := getMapString("sourceItemId", sourceAndTarget)
+		targetID, _ := getMapString("targetItemId", sourceAndTarget)
+		displayName, _ := getMapString("sourceItemDisplayName", sourceAndTarget)
+
+		results = append(results, pipelineDeploymentItemResultModel{
+			Type:        types.StringValue(stepType),
+			Status:      types.StringValue(stepStatus),
+			SourceID:    types.StringValue(sourceID),
+			TargetID:    types.StringValue(targetID),
+			DisplayName: types.StringValue(displayName),
+		})
+	}
+
+	return results
+}
+
+// pipelineDeploymentSteps returns the execution plan steps of a pipeline operation.
+func pipelineDeploymentSteps(operation map[string]interface{}) []map[string]interface{} {
+	executionPlan, _ := operation["executionPlan"].(map[string]interface{})
+	rawSteps, _ := executionPlan["steps"].([]interface{})
+
+	steps := make([]map[string]interface{}, 0, len(rawSteps))
+	for _, rawStep := range rawSteps {
+		if step, ok := rawStep.(map[string]interface{}); ok {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps
+}
@@ -3,15 +3,45 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"terraform-provider-microsoftfabric/internal/apiclient"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithModifyPlan   = &pipelineResource{}
+	_ resource.ResourceWithUpgradeState = &pipelineResource{}
+)
+
+// pipelineDefaultStages are the stages of a pipeline created without a stages list. They match the
+// stages the Power BI API created, so pipelines migrated from the workspaces list keep their stages.
+var pipelineDefaultStages = []string{"Development", "Test", "Production"}
+
+// pipelineStageType is the object type of a deployment pipeline stage.
+var pipelineStageType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":           types.StringType,
+		"order":        types.Int64Type,
+		"display_name": types.StringType,
+		"description":  types.StringType,
+		"is_public":    types.BoolType,
+		"workspace_id": types.StringType,
+	},
+}
+
 // Define the resource.
 type pipelineResource struct {
 	client *apiclient.APIClient
@@ -20,6 +50,7 @@ type pipelineResource struct {
 // Define the schema.
 func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -30,15 +61,46 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"description": schema.StringAttribute{
 				Optional: true,
 			},
-			"workspaces": schema.ListNestedAttribute{
-				Optional: true,
+			"stages": schema.ListNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The stages of the pipeline, in deployment order. Defaults to Development, Test and Production. Changing the number of stages recreates the pipeline.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"workspace_id": schema.StringAttribute{
-							Required: true,
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The Fabric ID of the stage.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"order": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The position of the stage in the pipeline, starting at 0.",
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+						"display_name": schema.StringAttribute{
+							Required:    true,
+							Description: "The display name of the stage.",
 						},
-						"stage_order": schema.Int64Attribute{
-							Required: true,
+						"description": schema.StringAttribute{
+							Optional:    true,
+							Description: "The description of the stage.",
+						},
+						"is_public": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether the stage is visible to users without access to the pipeline.",
+						},
+						"workspace_id": schema.StringAttribute{
+							Optional:    true,
+							Description: "The ID of the workspace assigned to the stage.",
 						},
 					},
 				},
@@ -50,17 +112,21 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	}
 }
 
-type pipelineWorkspaceModel struct {
+type pipelineStageModel struct {
+	ID          types.String `tfsdk:"id"`
+	Order       types.Int64  `tfsdk:"order"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Description types.String `tfsdk:"description"`
+	IsPublic    types.Bool   `tfsdk:"is_public"`
 	WorkspaceID types.String `tfsdk:"workspace_id"`
-	StageOrder  types.Int64  `tfsdk:"stage_order"`
 }
 
 type pipelineResourceModel struct {
-	ID          types.String             `tfsdk:"id"`
-	DisplayName types.String             `tfsdk:"display_name"`
-	Description types.String             `tfsdk:"description"`
-	Workspaces  []pipelineWorkspaceModel `tfsdk:"workspaces"`
-	LastUpdated types.String             `tfsdk:"last_updated"`
+	ID          types.String `tfsdk:"id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Description types.String `tfsdk:"description"`
+	Stages      types.List   `tfsdk:"stages"`
+	LastUpdated types.String `tfsdk:"last_updated"`
 }
 
 // Implement Metadata method.
@@ -73,6 +139,140 @@ func NewPipelineResource(client *apiclient.APIClient) resource.Resource {
 	return &pipelineResource{client: client}
 }
 
+// ModifyPlan recreates the pipeline when the number of stages changes, which Fabric does not support.
+func (r *pipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state pipelineResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Stages.IsUnknown() || plan.Stages.IsNull() || state.Stages.IsNull() {
+		return
+	}
+
+	if len(plan.Stages.Elements()) == len(state.Stages.Elements()) {
+		return
+	}
+
+	// The new pipeline gets new stage IDs.
+	var stages []pipelineStageModel
+	resp.Diagnostics.Append(plan.Stages.ElementsAs(ctx, &stages, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i := range stages {
+		stages[i].ID = types.StringUnknown()
+		stages[i].Order = types.Int64Unknown()
+	}
+
+	stagesList, diags := types.ListValueFrom(ctx, pipelineStageType, stages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("stages"), stagesList)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("stages"))
+}
+
+// UpgradeState moves the workspaces list of the Power BI pipeline schema into the stages list.
+func (r *pipelineResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"display_name": schema.StringAttribute{
+						Required: true,
+					},
+					"description": schema.StringAttribute{
+						Optional: true,
+					},
+					"workspaces": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"workspace_id": schema.StringAttribute{
+									Required: true,
+								},
+								"stage_order": schema.Int64Attribute{
+									Required: true,
+								},
+							},
+						},
+					},
+					"last_updated": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					ID          types.String `tfsdk:"id"`
+					DisplayName types.String `tfsdk:"display_name"`
+					Description types.String `tfsdk:"description"`
+					Workspaces  []struct {
+						WorkspaceID types.String `tfsdk:"workspace_id"`
+						StageOrder  types.Int64  `tfsdk:"stage_order"`
+					} `tfsdk:"workspaces"`
+					LastUpdated types.String `tfsdk:"last_updated"`
+				}
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// Stage IDs are filled in by the next refresh, which matches stages by order.
+				stages := make([]pipelineStageModel, len(pipelineDefaultStages))
+				for i, name := range pipelineDefaultStages {
+					stages[i] = pipelineStageModel{
+						ID:          types.StringNull(),
+						Order:       types.Int64Value(int64(i)),
+						DisplayName: types.StringValue(name),
+						Description: types.StringNull(),
+						IsPublic:    types.BoolValue(false),
+						WorkspaceID: types.StringNull(),
+					}
+				}
+				for _, workspace := range prior.Workspaces {
+					order := workspace.StageOrder.ValueInt64()
+					if order < 0 || order >= int64(len(stages)) {
+						resp.Diagnostics.AddError(
+							"Error upgrading pipeline state",
+							fmt.Sprintf("Workspace %s is assigned to unknown stage order %d.", workspace.WorkspaceID.ValueString(), order),
+						)
+						return
+					}
+					stages[order].WorkspaceID = workspace.WorkspaceID
+				}
+
+				stagesList, diags := types.ListValueFrom(ctx, pipelineStageType, stages)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := pipelineResourceModel{
+					ID:          prior.ID,
+					DisplayName: prior.DisplayName,
+					Description: prior.Description,
+					Stages:      stagesList,
+					LastUpdated: prior.LastUpdated,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
 // Implement CRUD operations.
 func (r *pipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan pipelineResourceModel
@@ -82,8 +282,25 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	var stages []pipelineStageModel
+	if plan.Stages.IsUnknown() || plan.Stages.IsNull() {
+		for _, name := range pipelineDefaultStages {
+			stages = append(stages, pipelineStageModel{
+				DisplayName: types.StringValue(name),
+				Description: types.StringNull(),
+				IsPublic:    types.BoolValue(false),
+				WorkspaceID: types.StringNull(),
+			})
+		}
+	} else {
+		resp.Diagnostics.Append(plan.Stages.ElementsAs(ctx, &stages, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create pipeline.
-	pipelineID, err := r.createPipeline(plan.DisplayName.ValueString(), plan.Description.ValueString())
+	pipelineID, err := r.createPipeline(plan.DisplayName.ValueString(), plan.Description.ValueString(), stages)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating pipeline",
@@ -91,21 +308,58 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		)
 		return
 	}
+	plan.ID = types.StringValue(pipelineID)
+
+	remoteStages, err := r.readStages(pipelineID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading pipeline stages",
+			"Could not read pipeline stages: "+err.Error(),
+		)
+		return
+	}
+	if len(remoteStages) != len(stages) {
+		resp.Diagnostics.AddError(
+			"Error creating pipeline",
+			fmt.Sprintf("The pipeline was created with %d stages instead of %d.", len(remoteStages), len(stages)),
+		)
+		return
+	}
 
-	// Assign workspaces if provided.
-	for _, workspace := range plan.Workspaces {
-		err = r.assignWorkspace(pipelineID, int(workspace.StageOrder.ValueInt64()), workspace.WorkspaceID.ValueString())
+	// Assign workspaces by stage ID.
+	for i := range stages {
+		stageID, ok := getMapString("id", remoteStages[i])
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Error reading pipeline stages",
+				fmt.Sprintf("Unexpected response format: stage %d of the pipeline has no 'id'.", i),
+			)
+			return
+		}
+		stages[i].ID = types.StringValue(stageID)
+		stages[i].Order = types.Int64Value(int64(i))
+
+		workspaceID := stages[i].WorkspaceID.ValueString()
+		if workspaceID == "" {
+			continue
+		}
+		err = r.assignWorkspace(pipelineID, stages[i].ID.ValueString(), workspaceID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error assigning workspace",
-				"Could not assign workspace: "+err.Error(),
+				fmt.Sprintf("Could not assign workspace to stage %s: %s", stages[i].DisplayName.ValueString(), err.Error()),
 			)
 			return
 		}
 	}
 
-	// Set ID and LastUpdated fields.
-	plan.ID = types.StringValue(pipelineID)
+	plan.Stages, diags = types.ListValueFrom(ctx, pipelineStageType, stages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set LastUpdated field.
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Set state.
@@ -127,6 +381,10 @@ func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	// Read pipeline.
 	pipeline, err := r.readPipeline(state.ID.ValueString())
 	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading pipeline",
 			"Could not read pipeline: "+err.Error(),
@@ -145,9 +403,62 @@ func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	description, _ := pipeline["description"].(string) // description is optional
 
+	// Refresh the stages and the workspaces assigned to them.
+	remoteStages, err := r.readStages(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading pipeline stages",
+			"Could not read pipeline stages: "+err.Error(),
+		)
+		return
+	}
+
+	var stateStages []pipelineStageModel
+	if !state.Stages.IsNull() && !state.Stages.IsUnknown() {
+		resp.Diagnostics.Append(state.Stages.ElementsAs(ctx, &stateStages, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	stages := make([]pipelineStageModel, len(remoteStages))
+	for i, remote := range remoteStages {
+		stageID, _ := remote["id"].(string)
+		stageName, _ := remote["displayName"].(string)
+		stageDescription, _ := remote["description"].(string)
+		isPublic, _ := remote["isPublic"].(bool)
+		workspaceID, _ := remote["workspaceId"].(string)
+
+		stage := pipelineStageModel{
+			ID:          types.StringValue(stageID),
+			Order:       types.Int64Value(int64(i)),
+			DisplayName: types.StringValue(stageName),
+			Description: types.StringNull(),
+			IsPublic:    types.BoolValue(isPublic),
+			WorkspaceID: types.StringNull(),
+		}
+		if i < len(stateStages) {
+			stage.Description = stateStages[i].Description
+		}
+		if !stage.Description.IsNull() || stageDescription != "" {
+			stage.Description = types.StringValue(stageDescription)
+		}
+		if workspaceID != "" {
+			stage.WorkspaceID = types.StringValue(workspaceID)
+		}
+		stages[i] = stage
+	}
+
 	// Set state
 	state.DisplayName = types.StringValue(displayName)
-	state.Description = types.StringValue(description)
+	if !state.Description.IsNull() || description != "" {
+		state.Description = types.StringValue(description)
+	}
+	state.Stages, diags = types.ListValueFrom(ctx, pipelineStageType, stages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	diags = resp.State.Set(ctx, state)
@@ -181,57 +492,67 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// Prepare to manage workspace assignments.
-	currentWorkspaceAssignments := make(map[string]int) // Track current workspace assignments.
-	for _, workspace := range state.Workspaces {
-		currentWorkspaceAssignments[workspace.WorkspaceID.ValueString()] = int(workspace.StageOrder.ValueInt64())
+	var planStages, stateStages []pipelineStageModel
+	resp.Diagnostics.Append(plan.Stages.ElementsAs(ctx, &planStages, false)...)
+	resp.Diagnostics.Append(state.Stages.ElementsAs(ctx, &stateStages, false)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for _, workspace := range plan.Workspaces {
-		workspaceID := workspace.WorkspaceID.ValueString()
-		newStageOrder := int(workspace.StageOrder.ValueInt64())
-
-		// Check the current stage order for this workspace.
-		if currentStageOrder, exists := currentWorkspaceAssignments[workspaceID]; exists {
-			if currentStageOrder != newStageOrder {
-				// If the stage order has changed, unassign from the current stage.
-				err = r.unassignWorkspace(state.ID.ValueString(), currentStageOrder, workspaceID)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error unassigning workspace",
-						"Could not unassign workspace: "+err.Error(),
-					)
-					return
-				}
-			}
+	// Stage IDs come from the state; the number of stages cannot change without replacement.
+	for i := range planStages {
+		planStages[i].ID = stateStages[i].ID
+		planStages[i].Order = types.Int64Value(int64(i))
+	}
 
-			// Remove from the current assignments map to avoid reassigning it.
-			delete(currentWorkspaceAssignments, workspaceID)
+	// Unassign first, so a workspace can move between stages.
+	for i, stage := range stateStages {
+		current := stage.WorkspaceID.ValueString()
+		if current == "" || current == planStages[i].WorkspaceID.ValueString() {
+			continue
 		}
-
-		// Assign the workspace to the new stage.
-		err = r.assignWorkspace(state.ID.ValueString(), newStageOrder, workspaceID)
+		err = r.unassignWorkspace(state.ID.ValueString(), stage.ID.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error assigning workspace",
-				"Could not assign workspace: "+err.Error(),
+				"Error unassigning workspace",
+				fmt.Sprintf("Could not unassign workspace from stage %s: %s", stage.DisplayName.ValueString(), err.Error()),
 			)
 			return
 		}
 	}
 
-	// Unassign any remaining workspaces in current assignments that are not in the plan.
-	for workspaceID, stageOrder := range currentWorkspaceAssignments {
-		err = r.unassignWorkspace(state.ID.ValueString(), stageOrder, workspaceID)
+	for i, stage := range planStages {
+		if !stage.DisplayName.Equal(stateStages[i].DisplayName) || !stage.Description.Equal(stateStages[i].Description) || !stage.IsPublic.Equal(stateStages[i].IsPublic) {
+			err = r.updateStage(state.ID.ValueString(), stage)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error updating pipeline stage",
+					fmt.Sprintf("Could not update stage %s: %s", stage.DisplayName.ValueString(), err.Error()),
+				)
+				return
+			}
+		}
+
+		workspaceID := stage.WorkspaceID.ValueString()
+		if workspaceID == "" || workspaceID == stateStages[i].WorkspaceID.ValueString() {
+			continue
+		}
+		err = r.assignWorkspace(state.ID.ValueString(), stage.ID.ValueString(), workspaceID)
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error unassigning workspace",
-				"Could not unassign workspace: "+err.Error(),
+				"Error assigning workspace",
+				fmt.Sprintf("Could not assign workspace to stage %s: %s", stage.DisplayName.ValueString(), err.Error()),
 			)
 			return
 		}
 	}
 
+	plan.Stages, diags = types.ListValueFrom(ctx, pipelineStageType, planStages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set LastUpdated field.
 	plan.ID = state.ID // Ensure the ID remains unchanged.
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
@@ -252,9 +573,18 @@ func (r *pipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	var stages []pipelineStageModel
+	resp.Diagnostics.Append(state.Stages.ElementsAs(ctx, &stages, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Unassign all workspaces before deletion.
-	for _, workspace := range state.Workspaces {
-		err := r.unassignWorkspace(state.ID.ValueString(), int(workspace.StageOrder.ValueInt64()), workspace.WorkspaceID.ValueString())
+	for _, stage := range stages {
+		if stage.WorkspaceID.ValueString() == "" {
+			continue
+		}
+		err := r.unassignWorkspace(state.ID.ValueString(), stage.ID.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error unassigning workspace during delete",
@@ -279,11 +609,22 @@ func (r *pipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 // Implement pipeline creation function.
-func (r *pipelineResource) createPipeline(displayName, description string) (string, error) {
-	url := "https://api.powerbi.com/v1.0/myorg/pipelines"
+func (r *pipelineResource) createPipeline(displayName, description string, stages []pipelineStageModel) (string, error) {
+	url := "https://api.fabric.microsoft.com/v1/deploymentPipelines"
+
+	stagesBody := make([]map[string]interface{}, 0, len(stages))
+	for _, stage := range stages {
+		stagesBody = append(stagesBody, map[string]interface{}{
+			"displayName": stage.DisplayName.ValueString(),
+			"description": stage.Description.ValueString(),
+			"isPublic":    stage.IsPublic.ValueBool(),
+		})
+	}
+
 	body := map[string]interface{}{
 		"displayName": displayName,
 		"description": description,
+		"stages":      stagesBody,
 	}
 
 	respBody, err := r.client.Post(url, body)
@@ -300,7 +641,7 @@ func (r *pipelineResource) createPipeline(displayName, description string) (stri
 
 // Implement pipeline read function.
 func (r *pipelineResource) readPipeline(id string) (map[string]interface{}, error) {
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/pipelines/%s", id)
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s", id)
 
 	respBody, err := r.client.Get(url)
 	if err != nil {
@@ -310,9 +651,44 @@ func (r *pipelineResource) readPipeline(id string) (map[string]interface{}, erro
 	return respBody, nil
 }
 
+// Implement pipeline stages read function. It returns the stages sorted by order.
+func (r *pipelineResource) readStages(id string) ([]map[string]interface{}, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/stages", id)
+
+	respBody, err := r.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := respBody["value"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format: 'value' key not found")
+	}
+
+	stages := make([]map[string]interface{}, 0, len(values))
+	for _, item := range values {
+		stage, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := stage["id"].(string); !ok {
+			return nil, fmt.Errorf("unexpected response format: stage 'id' key not found")
+		}
+		stages = append(stages, stage)
+	}
+
+	sort.SliceStable(stages, func(i, j int) bool {
+		orderI, _ := stages[i]["order"].(float64)
+		orderJ, _ := stages[j]["order"].(float64)
+		return orderI < orderJ
+	})
+
+	return stages, nil
+}
+
 // Implement pipeline update function.
 func (r *pipelineResource) updatePipeline(id, displayName, description string) error {
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/pipelines/%s", id)
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s", id)
 	body := map[string]interface{}{
 		"displayName": displayName,
 		"description": description,
@@ -327,9 +703,22 @@ func (r *pipelineResource) updatePipeline(id, displayName, description string) e
 	return nil
 }
 
+// Implement pipeline stage update function.
+func (r *pipelineResource) updateStage(pipelineID string, stage pipelineStageModel) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/stages/%s", pipelineID, stage.ID.ValueString())
+	body := map[string]interface{}{
+		"displayName": stage.DisplayName.ValueString(),
+		"description": stage.Description.ValueString(),
+		"isPublic":    stage.IsPublic.ValueBool(),
+	}
+
+	_, err := r.client.Patch(url, body)
+	return err
+}
+
 // Implement pipeline deletion function.
 func (r *pipelineResource) deletePipeline(id string) error {
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/pipelines/%s", id)
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s", id)
 
 	err := r.client.Delete(url)
 	if err != nil {
@@ -341,39 +730,20 @@ func (r *pipelineResource) deletePipeline(id string) error {
 }
 
 // Implement workspace assignment function.
-func (r *pipelineResource) assignWorkspace(pipelineID string, stageOrder int, workspaceID string) error {
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/pipelines/%s/stages/%d/assignWorkspace", pipelineID, stageOrder)
+func (r *pipelineResource) assignWorkspace(pipelineID, stageID, workspaceID string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/stages/%s/assignWorkspace", pipelineID, stageID)
 	body := map[string]interface{}{
 		"workspaceId": workspaceID,
 	}
 
-	respBody, err := r.client.Post(url, body)
-	if err != nil {
-		return err
-	}
-
-	if len(respBody) > 0 {
-		return nil
-	}
-
-	return nil
+	_, err := r.client.Post(url, body)
+	return err
 }
 
 // Implement workspace unassignment function.
-func (r *pipelineResource) unassignWorkspace(pipelineID string, stageOrder int, workspaceID string) error {
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/pipelines/%s/stages/%d/unassignWorkspace", pipelineID, stageOrder)
-	body := map[string]interface{}{
-		"workspaceId": workspaceID,
-	}
+func (r *pipelineResource) unassignWorkspace(pipelineID, stageID string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/stages/%s/unassignWorkspace", pipelineID, stageID)
 
-	respBody, err := r.client.Post(url, body)
-	if err != nil {
-		return err
-	}
-
-	if len(respBody) > 0 {
-		return nil
-	}
-
-	return nil
+	_, err := r.client.Post(url, map[string]interface{}{})
+	return err
 }
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &pipelineUserResource{}
+)
+
+// pipelineUserPrincipalTypes lists the principal types that can be granted access to a pipeline.
+var pipelineUserPrincipalTypes = []string{"User", "Group", "ServicePrincipal", "ServicePrincipalProfile"}
+
+// pipelineUserResource grants a principal access to a deployment pipeline.
+type pipelineUserResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the pipeline user resource.
+func (r *pipelineUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the role assignment in the form '<pipeline id>/<principal id>'.",
+			},
+			"pipeline_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the deployment pipeline.",
+				PlanModifiers: requiresReplace,
+			},
+			"principal_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The Microsoft Entra object ID of the user, group or service principal.",
+				PlanModifiers: requiresReplace,
+			},
+			"principal_type": schema.StringAttribute{
+				Required:      true,
+				Description:   fmt.Sprintf("The type of the principal: %s.", quotedList(pipelineUserPrincipalTypes)),
+				PlanModifiers: requiresReplace,
+			},
+			"role": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "The role of the principal. Deployment pipelines only support 'Admin', which is the default.",
+				PlanModifiers: requiresReplace,
+			},
+		},
+	}
+}
+
+// pipelineUserResourceModel defines the model of the pipeline user resource.
+type pipelineUserResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	PipelineID    types.String `tfsdk:"pipeline_id"`
+	PrincipalID   types.String `tfsdk:"principal_id"`
+	PrincipalType types.String `tfsdk:"principal_type"`
+	Role          types.String `tfsdk:"role"`
+}
+
+// Metadata sets the resource type name.
+func (r *pipelineUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_pipeline_user"
+}
+
+// NewPipelineUserResource creates a new pipeline user resource.
+func NewPipelineUserResource(client *apiclient.APIClient) resource.Resource {
+	return &pipelineUserResource{client: client}
+}
+
+// ValidateConfig checks the principal type and role.
+func (r *pipelineUserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config pipelineUserResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.PrincipalType.IsNull() && !config.PrincipalType.IsUnknown() {
+		valid := false
+		for _, principalType := range pipelineUserPrincipalTypes {
+			if config.PrincipalType.ValueString() == principalType {
+				valid = true
+			}
+		}
+		if !valid {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("principal_type"),
+				"Invalid principal type",
+				fmt.Sprintf("Unsupported principal type %q. Available options are %s.", config.PrincipalType.ValueString(), quotedList(pipelineUserPrincipalTypes)),
+			)
+		}
+	}
+
+	if !config.Role.IsNull() && !config.Role.IsUnknown() && config.Role.ValueString() != "Admin" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Invalid role",
+			fmt.Sprintf("Unsupported role %q. Deployment pipelines only support 'Admin'.", config.Role.ValueString()),
+		)
+	}
+}
+
+// Create grants the principal access to the pipeline.
+func (r *pipelineUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan pipelineUserResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Role.IsNull() || plan.Role.IsUnknown() {
+		plan.Role = types.StringValue("Admin")
+	}
+
+	body := map[string]interface{}{
+		"principal": map[string]interface{}{
+			"id":   plan.PrincipalID.ValueString(),
+			"type": plan.PrincipalType.ValueString(),
+		},
+		"role": plan.Role.ValueString(),
+	}
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/roleAssignments", plan.PipelineID.ValueString())
+	_, err := r.client.Post(url, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error granting pipeline access",
+			fmt.Sprintf("Could not grant %s access to pipeline: %v", plan.PrincipalID.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.PipelineID.ValueString() + "/" + plan.PrincipalID.ValueString())
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks that the principal still has access to the pipeline.
+func (r *pipelineUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state pipelineUserResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignment, err := r.readPipelineRoleAssignment(state.PipelineID.ValueString(), state.PrincipalID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading pipeline access",
+			"Could not read pipeline role assignments: "+err.Error(),
+		)
+		return
+	}
+	if assignment == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	principal, _ := assignment["principal"].(map[string]interface{})
+	if principalType, ok := principal["type"].(string); ok {
+		state.PrincipalType = types.StringValue(principalType)
+	}
+	if role, ok := assignment["role"].(string); ok {
+		state.Role = types.StringValue(role)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called because every argument requires replacement.
+func (r *pipelineUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan pipelineUserResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the access of the principal from the pipeline.
+func (r *pipelineUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state pipelineUserResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/roleAssignments/%s", state.PipelineID.ValueString(), state.PrincipalID.ValueString())
+	err := r.client.Delete(url)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error removing pipeline access",
+			fmt.Sprintf("Could not remove %s from pipeline: %v", state.PrincipalID.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// readPipelineRoleAssignment returns the role assignment of the principal, or nil when it has no access.
+// The role assignments are listed page by page until the principal is found.
+func (r *pipelineUserResource) readPipelineRoleAssignment(pipelineID, principalID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/deploymentPipelines/%s/roleAssignments", pipelineID)
+
+	for url != "" {
+		respBody, err := r.client.Get(url)
+		if err != nil {
+			return nil, err
+		}
+
+		assignments, ok := respBody["value"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format: 'value' key not found")
+		}
+
+		for _, item := range assignments {
+			assignment, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			principal, _ := assignment["principal"].(map[string]interface{})
+			if id, _ := principal["id"].(string); strings.EqualFold(id, principalID) {
+				return assignment, nil
+			}
+		}
+
+		url, _ = respBody["continuationUri"].(string)
+	}
+
+	return nil, nil
+}
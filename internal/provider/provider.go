@@ -126,5 +126,24 @@ func (p *microsoftFabricProvider) Resources(_ context.Context) []func() resource
 		func() resource.Resource { return NewShortcutResource(p.client) },
 		func() resource.Resource { return NewLakehouseTableResource(p.client) },
 		func() resource.Resource { return NewKqlDatabaseResource(p.client) },
+		func() resource.Resource { return NewSemanticModelResource(p.client) },
+		func() resource.Resource { return NewReportResource(p.client) },
+		func() resource.Resource { return NewWarehouseResource(p.client) },
+		func() resource.Resource { return NewEnvironmentResource(p.client) },
+		func() resource.Resource { return NewSparkJobDefinitionResource(p.client) },
+		func() resource.Resource { return NewKqlTableResource(p.client) },
+		func() resource.Resource { return NewKqlFunctionResource(p.client) },
+		func() resource.Resource { return NewKqlPolicyResource(p.client) },
+		func() resource.Resource { return NewKqlIngestionMappingResource(p.client) },
+		func() resource.Resource { return NewPipelineDeploymentResource(p.client) },
+		func() resource.Resource { return NewPipelineUserResource(p.client) },
+		func() resource.Resource { return NewWorkspaceGitSyncResource(p.client) },
+		func() resource.Resource { return NewShortcutsResource(p.client) },
+		func() resource.Resource { return NewLakehouseTableMaintenanceResource(p.client) },
+		func() resource.Resource { return NewLakehouseSQLEndpointRefreshResource(p.client) },
+		func() resource.Resource { return NewOneLakeFileResource(p.client) },
+		func() resource.Resource { return NewOneLakeDirectoryResource(p.client) },
+		func() resource.Resource { return NewOneLakeDataAccessRolesResource(p.client) },
+		func() resource.Resource { return NewItemPermissionsResource(p.client) },
 	}
 }
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &reportResource{}
+	_ resource.ResourceWithModifyPlan     = &reportResource{}
+)
+
+// reportResource defines the resource structure for managing Power BI reports.
+// The CRUD operations are shared with the other items created from local definition files.
+type reportResource struct {
+	definitionItemResource
+}
+
+// Schema defines the schema for the report resource.
+func (r *reportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the report.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the workspace where the report will be created.",
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The display name of the report.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional description of the report.",
+			},
+			"report_folder_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to a PBIR report folder (the '.Report' folder of a Power BI project). It must contain definition.pbir. Exactly one of report_folder_path and report_json_path must be set.",
+			},
+			"report_json_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to a legacy report.json file. Exactly one of report_folder_path and report_json_path must be set.",
+			},
+			"definition_pbir_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to the definition.pbir file used together with report_json_path. Can be omitted when semantic_model_id is set.",
+			},
+			"semantic_model_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the semantic model the report is bound to. When set, the dataset reference in definition.pbir is replaced by a connection to this semantic model.",
+			},
+			"definition_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the local definition files. A change of the local files triggers an updateDefinition call.",
+			},
+			"remote_definition_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the definition exported from Fabric after the last apply. Used to detect changes made outside of Terraform.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update made to the report.",
+			},
+		},
+	}
+}
+
+// reportResourceModel defines the model for managing the report's state.
+type reportResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	WorkspaceID          types.String `tfsdk:"workspace_id"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	Description          types.String `tfsdk:"description"`
+	ReportFolderPath     types.String `tfsdk:"report_folder_path"`
+	ReportJSONPath       types.String `tfsdk:"report_json_path"`
+	DefinitionPbirPath   types.String `tfsdk:"definition_pbir_path"`
+	SemanticModelID      types.String `tfsdk:"semantic_model_id"`
+	DefinitionHash       types.String `tfsdk:"definition_hash"`
+	RemoteDefinitionHash types.String `tfsdk:"remote_definition_hash"`
+	LastUpdated          types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns metadata about the report resource.
+func (r *reportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_report"
+}
+
+// NewReportResource creates a new report resource.
+func NewReportResource(client *apiclient.APIClient) resource.Resource {
+	return &reportResource{definitionItemResource{
+		client: client,
+		kind: definitionItemKind{
+			name:       "report",
+			collection: "reports",
+			newModel:   func() definitionItemModel { return &reportResourceModel{} },
+		},
+	}}
+}
+
+// ValidateConfig ensures exactly one definition source is configured.
+func (r *reportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config reportResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that are not yet known will be checked again during apply.
+	if config.ReportFolderPath.IsUnknown() || config.ReportJSONPath.IsUnknown() || config.DefinitionPbirPath.IsUnknown() || config.SemanticModelID.IsUnknown() {
+		return
+	}
+
+	if config.ReportFolderPath.IsNull() == config.ReportJSONPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("report_folder_path"),
+			"Invalid report definition",
+			"Exactly one of report_folder_path and report_json_path must be set.",
+		)
+		return
+	}
+
+	if !config.ReportFolderPath.IsNull() && !config.DefinitionPbirPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition_pbir_path"),
+			"Invalid report definition",
+			"definition_pbir_path can only be used together with report_json_path. A PBIR folder contains its own definition.pbir.",
+		)
+	}
+
+	if !config.ReportJSONPath.IsNull() && config.DefinitionPbirPath.IsNull() && config.SemanticModelID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition_pbir_path"),
+			"Invalid report definition",
+			"report_json_path requires either definition_pbir_path or semantic_model_id.",
+		)
+	}
+}
+
+// definitionParts reads the local PBIR folder or report.json and binds it to the semantic model if requested.
+// A changed semantic_model_id therefore changes the definition, and uploading it rebinds the report.
+func (m reportResourceModel) definitionParts() ([]definitionPart, error) {
+	var parts []definitionPart
+
+	switch {
+	case !m.ReportFolderPath.IsNull() && !m.ReportJSONPath.IsNull():
+		return nil, fmt.Errorf("only one of report_folder_path and report_json_path can be set")
+
+	case !m.ReportFolderPath.IsNull():
+		folderParts, err := readDefinitionFolder(m.ReportFolderPath.ValueString(), "")
+		if err != nil {
+			return nil, err
+		}
+		parts = folderParts
+
+	case !m.ReportJSONPath.IsNull():
+		reportJSON, err := readDefinitionFile(m.ReportJSONPath.ValueString(), "report.json")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, reportJSON)
+
+		if !m.DefinitionPbirPath.IsNull() {
+			pbir, err := readDefinitionFile(m.DefinitionPbirPath.ValueString(), "definition.pbir")
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, pbir)
+		}
+
+	default:
+		return nil, fmt.Errorf("one of report_folder_path and report_json_path must be set")
+	}
+
+	if !m.SemanticModelID.IsNull() {
+		return bindReportToSemanticModel(parts, m.SemanticModelID.ValueString())
+	}
+
+	for _, part := range parts {
+		if part.Path == "definition.pbir" {
+			return parts, nil
+		}
+	}
+	return nil, fmt.Errorf("the report definition does not contain definition.pbir")
+}
+
+// bindReportToSemanticModel points the dataset reference of definition.pbir to the given semantic model.
+// A minimal definition.pbir is added when the definition does not contain one.
+func bindReportToSemanticModel(parts []definitionPart, semanticModelID string) ([]definitionPart, error) {
+	datasetReference := map[string]interface{}{
+		"byConnection": map[string]interface{}{
+			"connectionString": "semanticmodelid=" + semanticModelID,
+		},
+	}
+
+	for i, part := range parts {
+		if part.Path != "definition.pbir" {
+			continue
+		}
+
+		var pbir map[string]interface{}
+		if err := json.Unmarshal(part.Payload, &pbir); err != nil {
+			return nil, fmt.Errorf("failed to parse definition.pbir: %w", err)
+		}
+		pbir["datasetReference"] = datasetReference
+
+		payload, err := json.MarshalIndent(pbir, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal definition.pbir: %w", err)
+		}
+		parts[i].Payload = payload
+		return parts, nil
+	}
+
+	payload, err := json.MarshalIndent(map[string]interface{}{
+		"version":          "4.0",
+		"datasetReference": datasetReference,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal definition.pbir: %w", err)
+	}
+
+	return append(parts, definitionPart{Path: "definition.pbir", Payload: payload}), nil
+}
+
+// definitionKnown reports whether the paths of the definition files and the semantic model ID are known.
+// The semantic model is usually created in the same apply, so its ID may not be known yet.
+func (m reportResourceModel) definitionKnown() bool {
+	return !m.ReportFolderPath.IsUnknown() && !m.ReportJSONPath.IsUnknown() && !m.DefinitionPbirPath.IsUnknown() && !m.SemanticModelID.IsUnknown()
+}
+
+// definitionFormat returns the export format matching the configured definition source.
+func (m reportResourceModel) definitionFormat() string {
+	if !m.ReportJSONPath.IsNull() {
+		return "PBIR-Legacy"
+	}
+	return "PBIR"
+}
+
+// definitionItem returns the attributes shared with the other items created from local definition files.
+func (m reportResourceModel) definitionItem() definitionItem {
+	return definitionItem{
+		definitionHashes: definitionHashes{
+			DefinitionHash:       m.DefinitionHash,
+			RemoteDefinitionHash: m.RemoteDefinitionHash,
+			LastUpdated:          m.LastUpdated,
+		},
+		ID:          m.ID,
+		WorkspaceID: m.WorkspaceID,
+		DisplayName: m.DisplayName,
+		Description: m.Description,
+	}
+}
+
+// setDefinitionItem stores the shared attributes in the model.
+func (m *reportResourceModel) setDefinitionItem(item definitionItem) {
+	m.ID = item.ID
+	m.WorkspaceID = item.WorkspaceID
+	m.DisplayName = item.DisplayName
+	m.Description = item.Description
+	m.DefinitionHash = item.DefinitionHash
+	m.RemoteDefinitionHash = item.RemoteDefinitionHash
+	m.LastUpdated = item.LastUpdated
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &semanticModelResource{}
+	_ resource.ResourceWithModifyPlan     = &semanticModelResource{}
+)
+
+// semanticModelResource defines the resource structure for managing semantic models.
+// The CRUD operations are shared with the other items created from local definition files.
+type semanticModelResource struct {
+	definitionItemResource
+}
+
+// Schema defines the schema for the semantic model resource.
+func (r *semanticModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the semantic model.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the workspace where the semantic model will be created.",
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The display name of the semantic model.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional description of the semantic model.",
+			},
+			"model_bim_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to a model.bim file (TMSL). Exactly one of model_bim_path and tmdl_folder_path must be set.",
+			},
+			"tmdl_folder_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to the TMDL 'definition' folder of the semantic model. Every file below it is uploaded as definition/<relative path>. Exactly one of model_bim_path and tmdl_folder_path must be set.",
+			},
+			"definition_pbism_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local path to the definition.pbism file of the semantic model.",
+			},
+			"definition_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the local definition files. A change of the local files triggers an updateDefinition call.",
+			},
+			"remote_definition_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the definition exported from Fabric after the last apply. Used to detect changes made outside of Terraform.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update made to the semantic model.",
+			},
+		},
+	}
+}
+
+// semanticModelResourceModel defines the model for managing the semantic model's state.
+type semanticModelResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	WorkspaceID          types.String `tfsdk:"workspace_id"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	Description          types.String `tfsdk:"description"`
+	ModelBimPath         types.String `tfsdk:"model_bim_path"`
+	TmdlFolderPath       types.String `tfsdk:"tmdl_folder_path"`
+	DefinitionPbismPath  types.String `tfsdk:"definition_pbism_path"`
+	DefinitionHash       types.String `tfsdk:"definition_hash"`
+	RemoteDefinitionHash types.String `tfsdk:"remote_definition_hash"`
+	LastUpdated          types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns metadata about the semantic model resource.
+func (r *semanticModelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_semantic_model"
+}
+
+// NewSemanticModelResource creates a new semantic model resource.
+func NewSemanticModelResource(client *apiclient.APIClient) resource.Resource {
+	return &semanticModelResource{definitionItemResource{
+		client: client,
+		kind: definitionItemKind{
+			name:       "semantic model",
+			collection: "semanticModels",
+			newModel:   func() definitionItemModel { return &semanticModelResourceModel{} },
+		},
+	}}
+}
+
+// ValidateConfig ensures exactly one definition source is configured.
+func (r *semanticModelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config semanticModelResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that are not yet known will be checked again during apply.
+	if config.ModelBimPath.IsUnknown() || config.TmdlFolderPath.IsUnknown() {
+		return
+	}
+
+	if config.ModelBimPath.IsNull() == config.TmdlFolderPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("model_bim_path"),
+			"Invalid semantic model definition",
+			"Exactly one of model_bim_path and tmdl_folder_path must be set.",
+		)
+	}
+}
+
+// definitionParts reads the local model.bim or TMDL folder together with definition.pbism.
+func (m semanticModelResourceModel) definitionParts() ([]definitionPart, error) {
+	pbism, err := readDefinitionFile(m.DefinitionPbismPath.ValueString(), "definition.pbism")
+	if err != nil {
+		return nil, err
+	}
+	parts := []definitionPart{pbism}
+
+	switch {
+	case !m.ModelBimPath.IsNull() && !m.TmdlFolderPath.IsNull():
+		return nil, fmt.Errorf("only one of model_bim_path and tmdl_folder_path can be set")
+
+	case !m.ModelBimPath.IsNull():
+		bim, err := readDefinitionFile(m.ModelBimPath.ValueString(), "m.bim")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, bim)
+
+	case !m.TmdlFolderPath.IsNull():
+		tmdlParts, err := readDefinitionFolder(m.TmdlFolderPath.ValueString(), "definition/")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, tmdlParts...)
+
+	default:
+		return nil, fmt.Errorf("one of model_bim_path and tmdl_folder_path must be set")
+	}
+
+	return parts, nil
+}
+
+// definitionKnown reports whether the paths of the definition files are known.
+func (m semanticModelResourceModel) definitionKnown() bool {
+	return !m.ModelBimPath.IsUnknown() && !m.TmdlFolderPath.IsUnknown() && !m.DefinitionPbismPath.IsUnknown()
+}
+
+// definitionFormat returns the export format matching the configured definition source.
+func (m semanticModelResourceModel) definitionFormat() string {
+	if !m.TmdlFolderPath.IsNull() {
+		return "TMDL"
+	}
+	return "TMSL"
+}
+
+// definitionItem returns the attributes shared with the other items created from local definition files.
+func (m semanticModelResourceModel) definitionItem() definitionItem {
+	return definitionItem{
+		definitionHashes: definitionHashes{
+			DefinitionHash:       m.DefinitionHash,
+			RemoteDefinitionHash: m.RemoteDefinitionHash,
+			LastUpdated:          m.LastUpdated,
+		},
+		ID:          m.ID,
+		WorkspaceID: m.WorkspaceID,
+		DisplayName: m.DisplayName,
+		Description: m.Description,
+	}
+}
+
+// setDefinitionItem stores the shared attributes in the model.
+func (m *semanticModelResourceModel) setDefinitionItem(item definitionItem) {
+	m.ID = item.ID
+	m.WorkspaceID = item.WorkspaceID
+	m.DisplayName = item.DisplayName
+	m.Description = item.Description
+	m.DefinitionHash = item.DefinitionHash
+	m.RemoteDefinitionHash = item.RemoteDefinitionHash
+	m.LastUpdated = item.LastUpdated
+}
@@ -2,16 +2,26 @@ package provider
 
 import (
     "context"
-    "encoding/json"
     "fmt"
+    "net/url"
+    "sort"
+    "strings"
     "terraform-provider-microsoftfabric/internal/apiclient"
     "time"
 
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
     "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ resource.ResourceWithConfigValidators = &shortcutResource{}
+    _ resource.ResourceWithValidateConfig   = &shortcutResource{}
+)
+
 // Define the shortcut resource.
 type shortcutResource struct {
     client *apiclient.APIClient
@@ -22,7 +32,7 @@ func (r *shortcutResource) Schema(_ context.Context, _ resource.SchemaRequest, r
         Attributes: map[string]schema.Attribute{
             "id": schema.StringAttribute{
                 Computed: true,
-                Description: "The unique identifier for the shortcut, generated by the system.",
+                Description: "The unique identifier for the shortcut in the form '<path>/<name>', using the name the shortcut was created with.",
             },
             "workspace_id": schema.StringAttribute{
                 Required: true,
@@ -40,69 +50,158 @@ func (r *shortcutResource) Schema(_ context.Context, _ resource.SchemaRequest, r
                 Required: true,
                 Description: "The name of the shortcut.",
             },
-            "target": schema.SingleNestedAttribute{
-                Required: true,
-                Description: "The target storage location where the shortcut points.",
+            "conflict_policy": schema.StringAttribute{
+                Optional: true,
+                Description: "What happens when a shortcut with the same name exists: 'Abort' (default), 'GenerateUniqueName', 'CreateOrOverwrite' or 'OverwriteOnly'. With 'GenerateUniqueName' the id holds the generated name.",
+            },
+            "target": shortcutTargetSchema(),
+            "last_updated": schema.StringAttribute{
+                Computed: true,
+                Description: "The timestamp of the last update made to the shortcut.",
+            },
+        },
+    }
+}
+
+// shortcutTargetSchema returns the schema of a shortcut target, shared by the single and bulk shortcut resources.
+func shortcutTargetSchema() schema.SingleNestedAttribute {
+    return schema.SingleNestedAttribute{
+        Required: true,
+        Description: "The target storage location where the shortcut points. Exactly one target must be set.",
+        Attributes: map[string]schema.Attribute{
+            "adls_gen2": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for an Azure Data Lake Storage Gen2 target.",
                 Attributes: map[string]schema.Attribute{
-                    "adls_gen2": schema.SingleNestedAttribute{
-                        Optional: true,
-                        Description: "Configuration details for an Azure Data Lake Storage Gen2 target.",
-                        Attributes: map[string]schema.Attribute{
-                            "location": schema.StringAttribute{
-                                Required:    true,
-                                Description: "The URL of the Azure Data Lake Storage location.",
-                            },
-                            "subpath": schema.StringAttribute{
-                                Required:    true,
-                                Description: "The subpath within the Azure Data Lake Storage to the resource.",
-                            },
-                            "connection_id": schema.StringAttribute{
-                                Required:    true,
-                                Description: "Gateway Connection ID",
-                            },
-                        },
+                    "location": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The URL of the Azure Data Lake Storage location.",
                     },
-                    "amazon_s3": schema.SingleNestedAttribute{
-                        Optional: true,
-                        Description: "Configuration details for an Amazon S3 target.",
-                        Attributes: map[string]schema.Attribute{
-                            "location": schema.StringAttribute{
-                                Required:    true,
-                                Description: "The URL of the Amazon S3 bucket.",
-                            },
-                            "subpath": schema.StringAttribute{
-                                Required:    true,
-                                Description: "The subpath within the Amazon S3 bucket to the resource.",
-                            },
-                            "connection_id": schema.StringAttribute{
-                                Required:    true,
-                                Description: "Gateway Connection ID",
-                            },
-                        },
+                    "subpath": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The subpath within the Azure Data Lake Storage to the resource.",
                     },
-                    "google_cloud_storage": schema.SingleNestedAttribute{
-                        Optional: true,
-                        Description: "Configuration details for a Google Cloud Storage target.",
-                        Attributes: map[string]schema.Attribute{
-                            "location": schema.StringAttribute{
-                                Required:    true,
-                                Description: "The URL of the Google Cloud Storage bucket.",
-                            },
-                            "subpath": schema.StringAttribute{
-                                Required:    true,
-                                Description: "The subpath within the Google Cloud Storage bucket to the resource.",
-                            },
-                            "connection_id": schema.StringAttribute{
-                                Required:    true,
-                                Description: "Gateway Connection ID",
-                            },
-                        },
+                    "connection_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "Gateway Connection ID",
                     },
                 },
             },
-            "last_updated": schema.StringAttribute{
-                Computed: true,
-                Description: "The timestamp of the last update made to the shortcut.",
+            "amazon_s3": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for an Amazon S3 target.",
+                Attributes: map[string]schema.Attribute{
+                    "location": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The URL of the Amazon S3 bucket.",
+                    },
+                    "subpath": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The subpath within the Amazon S3 bucket to the resource.",
+                    },
+                    "connection_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "Gateway Connection ID",
+                    },
+                },
+            },
+            "google_cloud_storage": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for a Google Cloud Storage target.",
+                Attributes: map[string]schema.Attribute{
+                    "location": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The URL of the Google Cloud Storage bucket.",
+                    },
+                    "subpath": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The subpath within the Google Cloud Storage bucket to the resource.",
+                    },
+                    "connection_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "Gateway Connection ID",
+                    },
+                },
+            },
+            "one_lake": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for a OneLake target, e.g. a table or folder of a lakehouse in another workspace.",
+                Attributes: map[string]schema.Attribute{
+                    "workspace_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The ID of the workspace of the target item.",
+                    },
+                    "item_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The ID of the target item, e.g. a lakehouse.",
+                    },
+                    "path": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The path within the target item, e.g. 'Tables/customers' or 'Files/raw'.",
+                    },
+                },
+            },
+            "s3_compatible": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for an S3 compatible target.",
+                Attributes: map[string]schema.Attribute{
+                    "location": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The URL of the S3 compatible endpoint.",
+                    },
+                    "bucket": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The name of the bucket.",
+                    },
+                    "subpath": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The subpath within the bucket to the resource.",
+                    },
+                    "connection_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "Gateway Connection ID",
+                    },
+                },
+            },
+            "dataverse": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for a Dataverse target.",
+                Attributes: map[string]schema.Attribute{
+                    "environment_domain": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The URL of the Dataverse environment, e.g. 'https://contoso.crm.dynamics.com'.",
+                    },
+                    "table_name": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The name of the Dataverse table.",
+                    },
+                    "deltalake_folder": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The Delta Lake folder of the table in the Dataverse storage.",
+                    },
+                    "connection_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "Gateway Connection ID",
+                    },
+                },
+            },
+            "azure_blob_storage": schema.SingleNestedAttribute{
+                Optional: true,
+                Description: "Configuration details for an Azure Blob Storage target.",
+                Attributes: map[string]schema.Attribute{
+                    "location": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The URL of the Azure Blob Storage account.",
+                    },
+                    "subpath": schema.StringAttribute{
+                        Required:    true,
+                        Description: "The subpath within the storage account, starting with the container.",
+                    },
+                    "connection_id": schema.StringAttribute{
+                        Required:    true,
+                        Description: "Gateway Connection ID",
+                    },
+                },
             },
         },
     }
@@ -110,20 +209,25 @@ func (r *shortcutResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 
 // Define the model for the shortcut resource.
 type shortcutResourceModel struct {
-    ID          types.String   `tfsdk:"id"`
-    WorkspaceID types.String   `tfsdk:"workspace_id"`
-    ItemID      types.String   `tfsdk:"item_id"`
-    Path        types.String   `tfsdk:"path"`
-    Name        types.String   `tfsdk:"name"`
-    Target      TargetModel    `tfsdk:"target"`
-    LastUpdated types.String   `tfsdk:"last_updated"`
+    ID             types.String `tfsdk:"id"`
+    WorkspaceID    types.String `tfsdk:"workspace_id"`
+    ItemID         types.String `tfsdk:"item_id"`
+    Path           types.String `tfsdk:"path"`
+    Name           types.String `tfsdk:"name"`
+    ConflictPolicy types.String `tfsdk:"conflict_policy"`
+    Target         TargetModel  `tfsdk:"target"`
+    LastUpdated    types.String `tfsdk:"last_updated"`
 }
 
 // Define a unified target model for multiple cloud storage options.
 type TargetModel struct {
-    ADLSGen2          *ADLSGen2TargetModel  `tfsdk:"adls_gen2"`
-    AmazonS3          *AmazonS3TargetModel  `tfsdk:"amazon_s3"`
-    GoogleCloudStorage *GCSModel             `tfsdk:"google_cloud_storage"`
+    ADLSGen2           *ADLSGen2TargetModel          `tfsdk:"adls_gen2"`
+    AmazonS3           *AmazonS3TargetModel          `tfsdk:"amazon_s3"`
+    GoogleCloudStorage *GCSModel                     `tfsdk:"google_cloud_storage"`
+    OneLake            *OneLakeTargetModel           `tfsdk:"one_lake"`
+    S3Compatible       *S3CompatibleTargetModel      `tfsdk:"s3_compatible"`
+    Dataverse          *DataverseTargetModel         `tfsdk:"dataverse"`
+    AzureBlobStorage   *AzureBlobStorageTargetModel  `tfsdk:"azure_blob_storage"`
 }
 
 // Define models for ADLS Gen2, Amazon S3, and Google Cloud Storage targets.
@@ -145,6 +249,87 @@ type GCSModel struct {
     ConnectionID types.String `tfsdk:"connection_id"`
 }
 
+type OneLakeTargetModel struct {
+    WorkspaceID types.String `tfsdk:"workspace_id"`
+    ItemID      types.String `tfsdk:"item_id"`
+    Path        types.String `tfsdk:"path"`
+}
+
+type S3CompatibleTargetModel struct {
+    Location     types.String `tfsdk:"location"`
+    Bucket       types.String `tfsdk:"bucket"`
+    Subpath      types.String `tfsdk:"subpath"`
+    ConnectionID types.String `tfsdk:"connection_id"`
+}
+
+type DataverseTargetModel struct {
+    EnvironmentDomain types.String `tfsdk:"environment_domain"`
+    TableName         types.String `tfsdk:"table_name"`
+    DeltaLakeFolder   types.String `tfsdk:"deltalake_folder"`
+    ConnectionID      types.String `tfsdk:"connection_id"`
+}
+
+type AzureBlobStorageTargetModel struct {
+    Location     types.String `tfsdk:"location"`
+    Subpath      types.String `tfsdk:"subpath"`
+    ConnectionID types.String `tfsdk:"connection_id"`
+}
+
+// shortcutTargetValidator checks that exactly one target type is configured.
+type shortcutTargetValidator struct{}
+
+func (v shortcutTargetValidator) Description(_ context.Context) string {
+    return "Exactly one of adls_gen2, amazon_s3, google_cloud_storage, one_lake, s3_compatible, dataverse or azure_blob_storage must be set in target."
+}
+
+func (v shortcutTargetValidator) MarkdownDescription(ctx context.Context) string {
+    return v.Description(ctx)
+}
+
+func (v shortcutTargetValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+    var target types.Object
+    diags := req.Config.GetAttribute(ctx, path.Root("target"), &target)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    validateShortcutTarget(target, path.Root("target"), v.Description(ctx), &resp.Diagnostics)
+}
+
+// validateShortcutTarget reports an error at attributePath unless exactly one target type is set.
+func validateShortcutTarget(target types.Object, attributePath path.Path, description string, diags *diag.Diagnostics) {
+    if target.IsNull() || target.IsUnknown() {
+        return
+    }
+
+    var configured []string
+    for name, value := range target.Attributes() {
+        if value.IsUnknown() {
+            return
+        }
+        if !value.IsNull() {
+            configured = append(configured, name)
+        }
+    }
+
+    if len(configured) != 1 {
+        sort.Strings(configured)
+        diags.AddAttributeError(
+            attributePath,
+            "Invalid shortcut target",
+            fmt.Sprintf("%s Configured: [%s].", description, strings.Join(configured, ", ")),
+        )
+    }
+}
+
+// ConfigValidators returns the validators of the shortcut configuration.
+func (r *shortcutResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+    return []resource.ConfigValidator{
+        shortcutTargetValidator{},
+    }
+}
+
 // Implement Metadata method.
 func (r *shortcutResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
     resp.TypeName = "microsoftfabric_shortcut"
@@ -155,6 +340,26 @@ func NewShortcutResource(client *apiclient.APIClient) resource.Resource {
     return &shortcutResource{client: client}
 }
 
+// ValidateConfig checks the conflict policy.
+func (r *shortcutResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+    var conflictPolicy types.String
+    diags := req.Config.GetAttribute(ctx, path.Root("conflict_policy"), &conflictPolicy)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() || conflictPolicy.IsNull() || conflictPolicy.IsUnknown() {
+        return
+    }
+
+    switch conflictPolicy.ValueString() {
+    case "Abort", "GenerateUniqueName", "CreateOrOverwrite", "OverwriteOnly":
+    default:
+        resp.Diagnostics.AddAttributeError(
+            path.Root("conflict_policy"),
+            "Invalid conflict policy",
+            fmt.Sprintf("Unsupported conflict policy %q. Available options are 'Abort', 'GenerateUniqueName', 'CreateOrOverwrite' and 'OverwriteOnly'.", conflictPolicy.ValueString()),
+        )
+    }
+}
+
 // Implement CRUD operations.
 func (r *shortcutResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
     // Retrieve values from plan.
@@ -166,7 +371,7 @@ func (r *shortcutResource) Create(ctx context.Context, req resource.CreateReques
     }
 
     // Create the shortcut.
-    err := r.createShortcut(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), plan.Path.ValueString(), plan.Name.ValueString(), plan.Target)
+    createdPath, createdName, err := r.createShortcut(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), plan.Path.ValueString(), plan.Name.ValueString(), plan.ConflictPolicy.ValueString(), plan.Target)
     if err != nil {
         resp.Diagnostics.AddError(
             "Error creating shortcut",
@@ -175,8 +380,8 @@ func (r *shortcutResource) Create(ctx context.Context, req resource.CreateReques
         return
     }
 
-    // Set the ID as "path/name".
-    plan.ID = types.StringValue(plan.Path.ValueString() + "/" + plan.Name.ValueString())
+    // Set the ID as "path/name" of the created shortcut.
+    plan.ID = types.StringValue(createdPath + "/" + createdName)
     plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850)) // Set LastUpdated to the current time
 
     // Set state.
@@ -193,10 +398,37 @@ func (r *shortcutResource) Read(ctx context.Context, req resource.ReadRequest, r
         return
     }
 
-    // Implement the logic to read the current state if necessary.
+    shortcutPath, shortcutName := splitShortcutID(state.ID.ValueString())
+    shortcut, err := r.getShortcut(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), shortcutPath, shortcutName)
+    if err != nil {
+        if isNotFoundError(err) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+        resp.Diagnostics.AddError(
+            "Error reading shortcut",
+            fmt.Sprintf("Could not read shortcut %s: %s", state.ID.ValueString(), err.Error()),
+        )
+        return
+    }
+
+    // A changed target shows up as a difference in the next plan.
+    target, ok := shortcut["target"].(map[string]interface{})
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Error reading shortcut",
+            "Unexpected response format: 'target' key not found",
+        )
+        return
+    }
+    state.Target = shortcutTargetFromResponse(target)
+
+    diags = resp.State.Set(ctx, state)
+    resp.Diagnostics.Append(diags...)
 }
 
-// The Update function that first deletes the existing shortcut and then creates a new one.
+// Update replaces the shortcut by creating the new one before deleting the old one, so a failed
+// create leaves the existing shortcut in place.
 func (r *shortcutResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
     // Retrieve the currently stored state.
     var state shortcutResourceModel
@@ -206,16 +438,6 @@ func (r *shortcutResource) Update(ctx context.Context, req resource.UpdateReques
         return
     }
 
-    // Delete the existing shortcut.
-    err := r.deleteShortcut(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), state.Path.ValueString(), state.Name.ValueString())
-    if err != nil {
-        resp.Diagnostics.AddError(
-            "Error deleting shortcut",
-            fmt.Sprintf("Could not delete existing shortcut: %s", err.Error()),
-        )
-        return
-    }
-
     // Prepare new values for the shortcut from the request.
     var plan shortcutResourceModel
     diags = req.Plan.Get(ctx, &plan)
@@ -224,23 +446,42 @@ func (r *shortcutResource) Update(ctx context.Context, req resource.UpdateReques
         return
     }
 
-    // Create the updated shortcut using the new values from the plan.
-    err = r.createShortcut(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), plan.Path.ValueString(), plan.Name.ValueString(), plan.Target)
+    oldPath, oldName := splitShortcutID(state.ID.ValueString())
+    sameLocation := plan.WorkspaceID.Equal(state.WorkspaceID) && plan.ItemID.Equal(state.ItemID) &&
+        plan.Path.ValueString() == oldPath && plan.Name.ValueString() == oldName
+
+    // A shortcut at the same location is overwritten in one call; otherwise the configured policy applies.
+    conflictPolicy := plan.ConflictPolicy.ValueString()
+    if sameLocation {
+        conflictPolicy = "CreateOrOverwrite"
+    }
+
+    createdPath, createdName, err := r.createShortcut(plan.WorkspaceID.ValueString(), plan.ItemID.ValueString(), plan.Path.ValueString(), plan.Name.ValueString(), conflictPolicy, plan.Target)
     if err != nil {
         resp.Diagnostics.AddError(
             "Error creating shortcut",
-            fmt.Sprintf("Could not create updated shortcut: %s", err.Error()),
+            fmt.Sprintf("Could not create updated shortcut, the existing shortcut was kept: %s", err.Error()),
         )
         return
     }
 
-    // Update the state to reflect the change.
-    state.Path = plan.Path // Update any specific fields as necessary
-    state.Name = plan.Name // Update the name, etc.
+    plan.ID = types.StringValue(createdPath + "/" + createdName)
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
-    // Save the updated state.
-    diags = resp.State.Set(ctx, &state)
+    // Save the new shortcut before removing the old one, so a failed delete does not lose track of it.
+    diags = resp.State.Set(ctx, plan)
     resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() || sameLocation {
+        return
+    }
+
+    err = r.deleteShortcut(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), oldPath, oldName)
+    if err != nil && !isNotFoundError(err) {
+        resp.Diagnostics.AddError(
+            "Error deleting shortcut",
+            fmt.Sprintf("Created the updated shortcut, but could not delete the previous shortcut %s: %s", state.ID.ValueString(), err.Error()),
+        )
+    }
 }
 
 func (r *shortcutResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -253,8 +494,9 @@ func (r *shortcutResource) Delete(ctx context.Context, req resource.DeleteReques
     }
 
     // Delete the shortcut using the helper function.
-    err := r.deleteShortcut(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), state.Path.ValueString(), state.Name.ValueString())
-    if err != nil {
+    shortcutPath, shortcutName := splitShortcutID(state.ID.ValueString())
+    err := r.deleteShortcut(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), shortcutPath, shortcutName)
+    if err != nil && !isNotFoundError(err) {
         resp.Diagnostics.AddError(
             "Error deleting shortcut",
             fmt.Sprintf("Could not delete shortcut: %s", err.Error()),
@@ -266,72 +508,216 @@ func (r *shortcutResource) Delete(ctx context.Context, req resource.DeleteReques
     resp.State.RemoveResource(ctx)
 }
 
-// Helper function to create a shortcut.
-func (r *shortcutResource) createShortcut(workspaceID, itemID, path, name string, target TargetModel) error {
-    url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/shortcuts", workspaceID, itemID)
+// Helper function to create a shortcut. It returns the path and name of the created shortcut, which
+// differ from the requested ones when the conflict policy generates a unique name.
+func (r *shortcutResource) createShortcut(workspaceID, itemID, shortcutPath, name, conflictPolicy string, target TargetModel) (string, string, error) {
+    requestURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/shortcuts", workspaceID, itemID)
+    if conflictPolicy != "" {
+        requestURL += "?shortcutConflictPolicy=" + url.QueryEscape(conflictPolicy)
+    }
 
-    // Prepare the request body based on the target type
-    var targetConfig map[string]interface{}
-    if target.ADLSGen2 != nil {
-        targetConfig = map[string]interface{}{
-            "adlsGen2": map[string]interface{}{
-                "location":     target.ADLSGen2.Location.ValueString(),
-                "subpath":      target.ADLSGen2.Subpath.ValueString(),
-                "connectionId": target.ADLSGen2.ConnectionID.ValueString(),
-            },
-        }
-    } else if target.AmazonS3 != nil {
-        targetConfig = map[string]interface{}{
-            "amazonS3": map[string]interface{}{
-                "location":     target.AmazonS3.Location.ValueString(),
-                "subpath":      target.AmazonS3.Subpath.ValueString(),
-                "connectionId": target.AmazonS3.ConnectionID.ValueString(),
-            },
-        }
-    } else if target.GoogleCloudStorage != nil {
-        targetConfig = map[string]interface{}{
-            "googleCloudStorage": map[string]interface{}{
-                "location":     target.GoogleCloudStorage.Location.ValueString(),
-                "subpath":      target.GoogleCloudStorage.Subpath.ValueString(),
-                "connectionId": target.GoogleCloudStorage.ConnectionID.ValueString(),
-            },
-        }
-    } else {
-        return fmt.Errorf("no valid target specified")
+    targetConfig, err := shortcutTargetBody(target)
+    if err != nil {
+        return "", "", err
     }
 
     // Prepare the complete body
     body := map[string]interface{}{
-        "path":   path,
+        "path":   shortcutPath,
         "name":   name,
         "target": targetConfig,
     }
 
-    bodyBytes, err := json.Marshal(body)
+    resp, err := r.client.PostWithLongRunningOperation(requestURL, body)
     if err != nil {
-        return fmt.Errorf("failed to marshal request body: %v", err)
+        return "", "", fmt.Errorf("failed to create shortcut: %w", err)
     }
 
-    resp, err := r.client.PostBytes(url, bodyBytes)
-    if err != nil {
-        return fmt.Errorf("failed to create shortcut: %v", err)
+    createdPath, ok := getMapString("path", resp)
+    if !ok || createdPath == "" {
+        createdPath = shortcutPath
+    }
+    createdName, ok := getMapString("name", resp)
+    if !ok || createdName == "" {
+        createdName = name
     }
 
-    // Log the API response for debugging or confirmation.
-    fmt.Printf("API Response: %+v\n", resp)
+    return strings.TrimPrefix(createdPath, "/"), createdName, nil
+}
+
+// Helper function to read a shortcut.
+func (r *shortcutResource) getShortcut(workspaceID, itemID, shortcutPath, name string) (map[string]interface{}, error) {
+    requestURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/shortcuts/%s/%s",
+        workspaceID, itemID, escapeShortcutPath(shortcutPath), url.PathEscape(name))
 
-    return nil
+    resp, err := r.client.Get(requestURL)
+    if err != nil {
+        return nil, err
+    }
+    if errorCode, ok := getMapString("errorCode", resp); ok {
+        message, _ := getMapString("message", resp)
+        return nil, fmt.Errorf("%s: %s", errorCode, message)
+    }
+
+    return resp, nil
 }
 
-func (r *shortcutResource) deleteShortcut(workspaceID, itemID, path, name string) error {
-    url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/shortcuts/%s/%s",
-        workspaceID, itemID, path, name)
+func (r *shortcutResource) deleteShortcut(workspaceID, itemID, shortcutPath, name string) error {
+    requestURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/shortcuts/%s/%s",
+        workspaceID, itemID, escapeShortcutPath(shortcutPath), url.PathEscape(name))
 
     // Perform the DELETE request.
-    err := r.client.Delete(url) // Assuming Delete only returns error
+    err := r.client.Delete(requestURL) // Assuming Delete only returns error
     if err != nil {
         return fmt.Errorf("failed to delete shortcut: %v", err)
     }
 
     return nil
-}
\ No newline at end of file
+}
+
+// splitShortcutID splits a "path/name" shortcut ID. The path may contain slashes, the name may not.
+func splitShortcutID(id string) (string, string) {
+    index := strings.LastIndex(id, "/")
+    if index < 0 {
+        return "", id
+    }
+    return id[:index], id[index+1:]
+}
+
+// escapeShortcutPath escapes every segment of a shortcut path, keeping the separators.
+func escapeShortcutPath(shortcutPath string) string {
+    segments := strings.Split(strings.Trim(shortcutPath, "/"), "/")
+    for i, segment := range segments {
+        segments[i] = url.PathEscape(segment)
+    }
+    return strings.Join(segments, "/")
+}
+
+// shortcutTargetBody builds the target of a create shortcut request.
+func shortcutTargetBody(target TargetModel) (map[string]interface{}, error) {
+    switch {
+    case target.ADLSGen2 != nil:
+        return map[string]interface{}{
+            "adlsGen2": map[string]interface{}{
+                "location":     target.ADLSGen2.Location.ValueString(),
+                "subpath":      target.ADLSGen2.Subpath.ValueString(),
+                "connectionId": target.ADLSGen2.ConnectionID.ValueString(),
+            },
+        }, nil
+    case target.AmazonS3 != nil:
+        return map[string]interface{}{
+            "amazonS3": map[string]interface{}{
+                "location":     target.AmazonS3.Location.ValueString(),
+                "subpath":      target.AmazonS3.Subpath.ValueString(),
+                "connectionId": target.AmazonS3.ConnectionID.ValueString(),
+            },
+        }, nil
+    case target.GoogleCloudStorage != nil:
+        return map[string]interface{}{
+            "googleCloudStorage": map[string]interface{}{
+                "location":     target.GoogleCloudStorage.Location.ValueString(),
+                "subpath":      target.GoogleCloudStorage.Subpath.ValueString(),
+                "connectionId": target.GoogleCloudStorage.ConnectionID.ValueString(),
+            },
+        }, nil
+    case target.OneLake != nil:
+        return map[string]interface{}{
+            "oneLake": map[string]interface{}{
+                "workspaceId": target.OneLake.WorkspaceID.ValueString(),
+                "itemId":      target.OneLake.ItemID.ValueString(),
+                "path":        target.OneLake.Path.ValueString(),
+            },
+        }, nil
+    case target.S3Compatible != nil:
+        return map[string]interface{}{
+            "s3Compatible": map[string]interface{}{
+                "location":     target.S3Compatible.Location.ValueString(),
+                "bucket":       target.S3Compatible.Bucket.ValueString(),
+                "subpath":      target.S3Compatible.Subpath.ValueString(),
+                "connectionId": target.S3Compatible.ConnectionID.ValueString(),
+            },
+        }, nil
+    case target.Dataverse != nil:
+        return map[string]interface{}{
+            "dataverse": map[string]interface{}{
+                "environmentDomain": target.Dataverse.EnvironmentDomain.ValueString(),
+                "tableName":         target.Dataverse.TableName.ValueString(),
+                "deltaLakeFolder":   target.Dataverse.DeltaLakeFolder.ValueString(),
+                "connectionId":      target.Dataverse.ConnectionID.ValueString(),
+            },
+        }, nil
+    case target.AzureBlobStorage != nil:
+        return map[string]interface{}{
+            "azureBlobStorage": map[string]interface{}{
+                "location":     target.AzureBlobStorage.Location.ValueString(),
+                "subpath":      target.AzureBlobStorage.Subpath.ValueString(),
+                "connectionId": target.AzureBlobStorage.ConnectionID.ValueString(),
+            },
+        }, nil
+    }
+
+    return nil, fmt.Errorf("no valid target specified")
+}
+
+// shortcutTargetFromResponse converts the target returned by the get shortcut API into the target model.
+func shortcutTargetFromResponse(target map[string]interface{}) TargetModel {
+    value := func(details map[string]interface{}, key string) types.String {
+        v, _ := getMapString(key, details)
+        return types.StringValue(v)
+    }
+
+    var model TargetModel
+    if details, ok := target["adlsGen2"].(map[string]interface{}); ok {
+        model.ADLSGen2 = &ADLSGen2TargetModel{
+            Location:     value(details, "location"),
+            Subpath:      value(details, "subpath"),
+            ConnectionID: value(details, "connectionId"),
+        }
+    }
+    if details, ok := target["amazonS3"].(map[string]interface{}); ok {
+        model.AmazonS3 = &AmazonS3TargetModel{
+            Location:     value(details, "location"),
+            Subpath:      value(details, "subpath"),
+            ConnectionID: value(details, "connectionId"),
+        }
+    }
+    if details, ok := target["googleCloudStorage"].(map[string]interface{}); ok {
+        model.GoogleCloudStorage = &GCSModel{
+            Location:     value(details, "location"),
+            Subpath:      value(details, "subpath"),
+            ConnectionID: value(details, "connectionId"),
+        }
+    }
+    if details, ok := target["oneLake"].(map[string]interface{}); ok {
+        model.OneLake = &OneLakeTargetModel{
+            WorkspaceID: value(details, "workspaceId"),
+            ItemID:      value(details, "itemId"),
+            Path:        value(details, "path"),
+        }
+    }
+    if details, ok := target["s3Compatible"].(map[string]interface{}); ok {
+        model.S3Compatible = &S3CompatibleTargetModel{
+            Location:     value(details, "location"),
+            Bucket:       value(details, "bucket"),
+            Subpath:      value(details, "subpath"),
+            ConnectionID: value(details, "connectionId"),
+        }
+    }
+    if details, ok := target["dataverse"].(map[string]interface{}); ok {
+        model.Dataverse = &DataverseTargetModel{
+            EnvironmentDomain: value(details, "environmentDomain"),
+            TableName:         value(details, "tableName"),
+            DeltaLakeFolder:   value(details, "deltaLakeFolder"),
+            ConnectionID:      value(details, "connectionId"),
+        }
+    }
+    if details, ok := target["azureBlobStorage"].(map[string]interface{}); ok {
+        model.AzureBlobStorage = &AzureBlobStorageTargetModel{
+            Location:     value(details, "location"),
+            Subpath:      value(details, "subpath"),
+            ConnectionID: value(details, "connectionId"),
+        }
+    }
+
+    return model
+}
@@ -0,0 +1,492 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &shortcutsResource{}
+)
+
+// defaultShortcutsConcurrency is the number of shortcut requests sent in parallel unless configured otherwise.
+const defaultShortcutsConcurrency = 8
+
+// shortcutsResource manages many shortcuts of a single item, such as a lakehouse, as one resource.
+type shortcutsResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the shortcuts resource.
+func (r *shortcutsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of shortcuts of one item. Existing shortcuts are listed once and only the shortcuts that differ are created, overwritten or deleted, several at a time. Shortcuts of the item that are not declared here are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the shortcut set in the form '<workspace id>/<item id>'.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the workspace of the item.",
+				PlanModifiers: requiresReplace,
+			},
+			"item_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the item the shortcuts are created in, e.g. a lakehouse.",
+				PlanModifiers: requiresReplace,
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultShortcutsConcurrency),
+				Description: fmt.Sprintf("The maximum number of shortcut requests sent in parallel. Defaults to %d.", defaultShortcutsConcurrency),
+			},
+			"shortcuts": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "The shortcuts, keyed by a name of your choice. A declared shortcut that already exists is adopted, and overwritten when its target differs.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Required:    true,
+							Description: "The path within the item to the shortcut, e.g. 'Tables' or 'Files/raw'.",
+						},
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the shortcut.",
+						},
+						"target": shortcutTargetSchema(),
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update made to the shortcuts.",
+			},
+		},
+	}
+}
+
+// shortcutsResourceModel defines the model of the shortcuts resource.
+type shortcutsResourceModel struct {
+	ID             types.String                       `tfsdk:"id"`
+	WorkspaceID    types.String                       `tfsdk:"workspace_id"`
+	ItemID         types.String                       `tfsdk:"item_id"`
+	MaxConcurrency types.Int64                        `tfsdk:"max_concurrency"`
+	Shortcuts      map[string]shortcutDefinitionModel `tfsdk:"shortcuts"`
+	LastUpdated    types.String                       `tfsdk:"last_updated"`
+}
+
+// shortcutDefinitionModel defines one shortcut of the shortcuts resource.
+type shortcutDefinitionModel struct {
+	Path   types.String `tfsdk:"path"`
+	Name   types.String `tfsdk:"name"`
+	Target TargetModel  `tfsdk:"target"`
+}
+
+// location returns the "path/name" of the shortcut within its item.
+func (m shortcutDefinitionModel) location() string {
+	return strings.Trim(m.Path.ValueString(), "/") + "/" + m.Name.ValueString()
+}
+
+// Metadata sets the resource type name.
+func (r *shortcutsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_shortcuts"
+}
+
+// NewShortcutsResource creates the shortcuts resource.
+func NewShortcutsResource(client *apiclient.APIClient) resource.Resource {
+	return &shortcutsResource{client: client}
+}
+
+// ValidateConfig checks every shortcut target and that no two shortcuts share a location.
+func (r *shortcutsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var maxConcurrency types.Int64
+	diags := req.Config.GetAttribute(ctx, path.Root("max_concurrency"), &maxConcurrency)
+	resp.Diagnostics.Append(diags...)
+	if !maxConcurrency.IsNull() && !maxConcurrency.IsUnknown() && maxConcurrency.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrency"),
+			"Invalid max concurrency",
+			"max_concurrency must be at least 1.",
+		)
+	}
+
+	var shortcuts types.Map
+	diags = req.Config.GetAttribute(ctx, path.Root("shortcuts"), &shortcuts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || shortcuts.IsNull() || shortcuts.IsUnknown() {
+		return
+	}
+
+	locations := make(map[string]string)
+	keys := make([]string, 0, len(shortcuts.Elements()))
+	for key := range shortcuts.Elements() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		shortcut, ok := shortcuts.Elements()[key].(types.Object)
+		if !ok || shortcut.IsNull() || shortcut.IsUnknown() {
+			continue
+		}
+		attributes := shortcut.Attributes()
+		shortcutPath := path.Root("shortcuts").AtMapKey(key)
+
+		if target, ok := attributes["target"].(types.Object); ok {
+			validateShortcutTarget(target, shortcutPath.AtName("target"), shortcutTargetValidator{}.Description(ctx), &resp.Diagnostics)
+		}
+
+		pathValue, pathOK := attributes["path"].(types.String)
+		nameValue, nameOK := attributes["name"].(types.String)
+		if !pathOK || !nameOK || pathValue.IsUnknown() || nameValue.IsUnknown() {
+			continue
+		}
+		location := shortcutDefinitionModel{Path: pathValue, Name: nameValue}.location()
+		if other, exists := locations[location]; exists {
+			resp.Diagnostics.AddAttributeError(
+				shortcutPath,
+				"Duplicate shortcut",
+				fmt.Sprintf("Shortcuts %q and %q both point to %q.", other, key, location),
+			)
+			continue
+		}
+		locations[location] = key
+	}
+}
+
+// Create creates all declared shortcuts that do not exist yet with the same target.
+func (r *shortcutsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan shortcutsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied, diags := r.applyShortcuts(plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if applied == nil {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.WorkspaceID.ValueString() + "/" + plan.ItemID.ValueString())
+	plan.Shortcuts = applied
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Keep the shortcuts that were created even if others failed.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the targets of the managed shortcuts and forgets shortcuts that no longer exist.
+func (r *shortcutsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state shortcutsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.listShortcuts(state.WorkspaceID.ValueString(), state.ItemID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading shortcuts",
+			fmt.Sprintf("Could not list the shortcuts of item %s: %s", state.ItemID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	shortcuts := make(map[string]shortcutDefinitionModel, len(state.Shortcuts))
+	for key, shortcut := range state.Shortcuts {
+		target, ok := existing[shortcut.location()]
+		if !ok {
+			continue
+		}
+		shortcut.Target = target
+		shortcuts[key] = shortcut
+	}
+	state.Shortcuts = shortcuts
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update creates and overwrites the changed shortcuts before deleting the removed ones.
+func (r *shortcutsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan shortcutsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state shortcutsResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied, diags := r.applyShortcuts(plan, state.Shortcuts)
+	resp.Diagnostics.Append(diags...)
+	if applied == nil {
+		// Nothing was changed, keep the current state.
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Shortcuts = applied
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes all managed shortcuts.
+func (r *shortcutsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state shortcutsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := sortedShortcutKeys(state.Shortcuts)
+	remaining := make(map[string]shortcutDefinitionModel)
+	var mutex sync.Mutex
+
+	forEachConcurrently(len(keys), int(state.MaxConcurrency.ValueInt64()), func(i int) {
+		key := keys[i]
+		shortcut := state.Shortcuts[key]
+		err := r.deleteShortcut(state.WorkspaceID.ValueString(), state.ItemID.ValueString(), shortcut)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil && !isNotFoundError(err) {
+			remaining[key] = shortcut
+			resp.Diagnostics.AddAttributeError(
+				path.Root("shortcuts").AtMapKey(key),
+				"Error deleting shortcut",
+				fmt.Sprintf("Could not delete shortcut %s: %s", shortcut.location(), err.Error()),
+			)
+		}
+	})
+
+	if len(remaining) > 0 {
+		// Keep the shortcuts that could not be deleted so that the next apply retries them.
+		state.Shortcuts = remaining
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// applyShortcuts makes the item's shortcuts match the plan. Existing shortcuts are listed once; shortcuts
+// that are missing or point to another target are created or overwritten, then the shortcuts of prior that
+// are no longer declared are deleted. It returns the shortcuts to store in the state, which keeps the prior
+// definition of every shortcut whose change failed, or nil if the shortcuts could not be listed.
+func (r *shortcutsResource) applyShortcuts(plan shortcutsResourceModel, prior map[string]shortcutDefinitionModel) (map[string]shortcutDefinitionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	workspaceID := plan.WorkspaceID.ValueString()
+	itemID := plan.ItemID.ValueString()
+
+	existing, err := r.listShortcuts(workspaceID, itemID)
+	if err != nil {
+		diags.AddError(
+			"Error listing shortcuts",
+			fmt.Sprintf("Could not list the shortcuts of item %s: %s", itemID, err.Error()),
+		)
+		return nil, diags
+	}
+
+	applied := make(map[string]shortcutDefinitionModel, len(plan.Shortcuts))
+	desiredLocations := make(map[string]bool, len(plan.Shortcuts))
+	var changed []string
+	for _, key := range sortedShortcutKeys(plan.Shortcuts) {
+		shortcut := plan.Shortcuts[key]
+		desiredLocations[shortcut.location()] = true
+
+		target, exists := existing[shortcut.location()]
+		if exists && sameShortcutTarget(target, shortcut.Target) {
+			applied[key] = shortcut
+			continue
+		}
+		changed = append(changed, key)
+	}
+
+	var removed []string
+	for _, key := range sortedShortcutKeys(prior) {
+		location := prior[key].location()
+		if _, exists := existing[location]; exists && !desiredLocations[location] {
+			removed = append(removed, key)
+		}
+	}
+
+	concurrency := int(plan.MaxConcurrency.ValueInt64())
+	var mutex sync.Mutex
+
+	// Create first, so that a shortcut that moved to a new location is only deleted once its replacement exists.
+	forEachConcurrently(len(changed), concurrency, func(i int) {
+		key := changed[i]
+		shortcut := plan.Shortcuts[key]
+		conflictPolicy := ""
+		if _, exists := existing[shortcut.location()]; exists {
+			conflictPolicy = "CreateOrOverwrite"
+		}
+
+		_, _, err := r.createShortcut(workspaceID, itemID, shortcut, conflictPolicy)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil {
+			if previous, ok := prior[key]; ok {
+				applied[key] = previous
+			}
+			diags.AddAttributeError(
+				path.Root("shortcuts").AtMapKey(key),
+				"Error creating shortcut",
+				fmt.Sprintf("Could not create shortcut %s: %s", shortcut.location(), err.Error()),
+			)
+			return
+		}
+		applied[key] = shortcut
+	})
+
+	forEachConcurrently(len(removed), concurrency, func(i int) {
+		key := removed[i]
+		shortcut := prior[key]
+		err := r.deleteShortcut(workspaceID, itemID, shortcut)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil && !isNotFoundError(err) {
+			// Keep a removed shortcut in the state so that the next apply deletes it again. A shortcut
+			// that moved is already stored under its key with the new location.
+			if _, declared := plan.Shortcuts[key]; !declared {
+				applied[key] = shortcut
+			}
+			diags.AddAttributeError(
+				path.Root("shortcuts").AtMapKey(key),
+				"Error deleting shortcut",
+				fmt.Sprintf("Could not delete shortcut %s: %s", shortcut.location(), err.Error()),
+			)
+		}
+	})
+
+	return applied, diags
+}
+
+// listShortcuts returns the targets of all shortcuts of an item, keyed by "path/name".
+func (r *shortcutsResource) listShortcuts(workspaceID, itemID string) (map[string]TargetModel, error) {
+	shortcuts := make(map[string]TargetModel)
+	requestURL := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/items/%s/shortcuts", workspaceID, itemID)
+
+	for requestURL != "" {
+		resp, err := r.client.Get(requestURL)
+		if err != nil {
+			return nil, err
+		}
+		if errorCode, ok := getMapString("errorCode", resp); ok {
+			message, _ := getMapString("message", resp)
+			return nil, fmt.Errorf("%s: %s", errorCode, message)
+		}
+
+		values, _ := resp["value"].([]interface{})
+		for _, value := range values {
+			shortcut, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			shortcutPath, _ := getMapString("path", shortcut)
+			name, _ := getMapString("name", shortcut)
+			target, _ := shortcut["target"].(map[string]interface{})
+			shortcuts[strings.Trim(shortcutPath, "/")+"/"+name] = shortcutTargetFromResponse(target)
+		}
+
+		requestURL, _ = getMapString("continuationUri", resp)
+	}
+
+	return shortcuts, nil
+}
+
+// createShortcut creates one shortcut with the given conflict policy.
+func (r *shortcutsResource) createShortcut(workspaceID, itemID string, shortcut shortcutDefinitionModel, conflictPolicy string) (string, string, error) {
+	single := &shortcutResource{client: r.client}
+	return single.createShortcut(workspaceID, itemID, shortcut.Path.ValueString(), shortcut.Name.ValueString(), conflictPolicy, shortcut.Target)
+}
+
+// deleteShortcut deletes one shortcut.
+func (r *shortcutsResource) deleteShortcut(workspaceID, itemID string, shortcut shortcutDefinitionModel) error {
+	single := &shortcutResource{client: r.client}
+	return single.deleteShortcut(workspaceID, itemID, strings.Trim(shortcut.Path.ValueString(), "/"), shortcut.Name.ValueString())
+}
+
+// sameShortcutTarget reports whether two targets would be sent to Fabric identically.
+func sameShortcutTarget(a, b TargetModel) bool {
+	bodyA, errA := shortcutTargetBody(a)
+	bodyB, errB := shortcutTargetBody(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return reflect.DeepEqual(bodyA, bodyB)
+}
+
+// sortedShortcutKeys returns the keys of the shortcuts in a stable order, so that requests and
+// diagnostics are reproducible.
+func sortedShortcutKeys(shortcuts map[string]shortcutDefinitionModel) []string {
+	keys := make([]string, 0, len(shortcuts))
+	for key := range shortcuts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// forEachConcurrently calls fn for every index below n, running at most limit calls at the same time.
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, limit)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
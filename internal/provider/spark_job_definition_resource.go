@@ -0,0 +1,564 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithModifyPlan = &sparkJobDefinitionResource{}
+)
+
+// sparkJobDefinitionFormat is the definition format of Spark job definitions.
+const sparkJobDefinitionFormat = "SparkJobDefinitionV1"
+
+// Define the Spark job definition resource.
+type sparkJobDefinitionResource struct {
+	client *apiclient.APIClient
+}
+
+// Define the schema for the Spark job definition resource.
+func (r *sparkJobDefinitionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the Spark job definition.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the workspace where the Spark job definition will be created.",
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The display name of the Spark job definition.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional description of the Spark job definition.",
+			},
+			"language": schema.StringAttribute{
+				Required:    true,
+				Description: "Language of the job. Available options include 'Python', 'Scala' and 'R'.",
+			},
+			"main_file_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local path to the main executable file (.py, .jar or .R). It is uploaded to the Main folder of the Spark job definition in OneLake.",
+			},
+			"main_class": schema.StringAttribute{
+				Optional:    true,
+				Description: "Main class of the job. Required for Scala/Java jobs.",
+			},
+			"command_line_arguments": schema.StringAttribute{
+				Optional:    true,
+				Description: "Command-line arguments passed to the main file, separated by spaces.",
+			},
+			"reference_file_paths": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Local paths to reference files (.py, .jar, .R or data files). They are uploaded to the Libs folder of the Spark job definition in OneLake.",
+			},
+			"default_lakehouse_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the default lakehouse of the job.",
+			},
+			"additional_lakehouse_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of additional lakehouses the job can access.",
+			},
+			"environment_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the environment the job runs in.",
+			},
+			"files_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the local main and reference files. A change of the files triggers a new upload.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update.",
+			},
+		},
+	}
+}
+
+// Define the model for the Spark job definition resource.
+type sparkJobDefinitionResourceModel struct {
+	ID                     types.String   `tfsdk:"id"`
+	WorkspaceID            types.String   `tfsdk:"workspace_id"`
+	DisplayName            types.String   `tfsdk:"display_name"`
+	Description            types.String   `tfsdk:"description"`
+	Language               types.String   `tfsdk:"language"`
+	MainFilePath           types.String   `tfsdk:"main_file_path"`
+	MainClass              types.String   `tfsdk:"main_class"`
+	CommandLineArguments   types.String   `tfsdk:"command_line_arguments"`
+	ReferenceFilePaths     []types.String `tfsdk:"reference_file_paths"`
+	DefaultLakehouseID     types.String   `tfsdk:"default_lakehouse_id"`
+	AdditionalLakehouseIDs []types.String `tfsdk:"additional_lakehouse_ids"`
+	EnvironmentID          types.String   `tfsdk:"environment_id"`
+	FilesHash              types.String   `tfsdk:"files_hash"`
+	LastUpdated            types.String   `tfsdk:"last_updated"`
+}
+
+// sparkJobDefinitionV1 is the content of the SparkJobDefinitionV1.json definition part.
+type sparkJobDefinitionV1 struct {
+	ExecutableFile             string      `json:"executableFile"`
+	DefaultLakehouseArtifactID string      `json:"defaultLakehouseArtifactId"`
+	MainClass                  string      `json:"mainClass"`
+	AdditionalLakehouseIDs     []string    `json:"additionalLakehouseIds"`
+	RetryPolicy                interface{} `json:"retryPolicy"`
+	CommandLineArguments       string      `json:"commandLineArguments"`
+	AdditionalLibraryURIs      []string    `json:"additionalLibraryUris"`
+	Language                   string      `json:"language"`
+	EnvironmentArtifactID      *string     `json:"environmentArtifactId"`
+}
+
+// Implement Metadata method.
+func (r *sparkJobDefinitionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_spark_job_definition"
+}
+
+// Define the provider.
+func NewSparkJobDefinitionResource(client *apiclient.APIClient) resource.Resource {
+	return &sparkJobDefinitionResource{client: client}
+}
+
+// ModifyPlan hashes the local job files so that content changes show up in the plan.
+func (r *sparkJobDefinitionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan sparkJobDefinitionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.MainFilePath.IsUnknown() {
+		return
+	}
+	for _, referencePath := range plan.ReferenceFilePaths {
+		if referencePath.IsUnknown() {
+			return
+		}
+	}
+
+	files, err := sparkJobDefinitionFiles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Spark job definition files",
+			"Could not read local Spark job definition files: "+err.Error(),
+		)
+		return
+	}
+	plan.FilesHash = types.StringValue(hashDefinitionParts(files))
+
+	// Changed job files are uploaded again without a config change, so the framework left this known.
+	if !req.State.Raw.IsNull() {
+		var state sparkJobDefinitionResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.FilesHash.Equal(state.FilesHash) {
+			plan.LastUpdated = types.StringUnknown()
+		}
+	}
+
+	diags = resp.Plan.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Implement CRUD operations.
+func (r *sparkJobDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sparkJobDefinitionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, err := sparkJobDefinitionFiles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Spark job definition files",
+			"Could not read local Spark job definition files: "+err.Error(),
+		)
+		return
+	}
+
+	// The item has to exist before its files can be uploaded to OneLake.
+	sparkJobDefinitionID, err := r.createSparkJobDefinition(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Spark job definition",
+			"Could not create Spark job definition: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(sparkJobDefinitionID)
+	plan.FilesHash = types.StringValue(hashDefinitionParts(files))
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Save the item right away so that a failed upload does not orphan it.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.uploadFiles(plan, files); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading Spark job definition files",
+			"Could not upload files to OneLake: "+err.Error(),
+		)
+		return
+	}
+
+	if err := r.updateDefinition(plan, files); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Spark job definition",
+			"Could not update Spark job definition: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *sparkJobDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sparkJobDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/sparkJobDefinitions/%s", state.WorkspaceID.ValueString(), state.ID.ValueString())
+	sparkJobDefinition, err := r.client.Get(url)
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading Spark job definition",
+			"Could not read Spark job definition: "+err.Error(),
+		)
+		return
+	}
+
+	displayName, ok := sparkJobDefinition["displayName"].(string)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error reading Spark job definition",
+			"Unexpected response format: 'displayName' key not found or not a string",
+		)
+		return
+	}
+	description, _ := sparkJobDefinition["description"].(string) // description is optional
+
+	state.DisplayName = types.StringValue(displayName)
+	if !state.Description.IsNull() || description != "" {
+		state.Description = types.StringValue(description)
+	}
+
+	// Refresh the job settings from the exported definition.
+	parts, err := getItemDefinition(r.client, state.WorkspaceID.ValueString(), state.ID.ValueString(), sparkJobDefinitionFormat)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting Spark job definition",
+			"Could not export Spark job definition: "+err.Error(),
+		)
+		return
+	}
+
+	for _, part := range parts {
+		if part.Path != "SparkJobDefinitionV1.json" {
+			continue
+		}
+
+		var definition sparkJobDefinitionV1
+		if err := json.Unmarshal(part.Payload, &definition); err != nil {
+			resp.Diagnostics.AddError(
+				"Error exporting Spark job definition",
+				"Could not parse SparkJobDefinitionV1.json: "+err.Error(),
+			)
+			return
+		}
+		setSparkJobDefinitionFromV1(&state, definition)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *sparkJobDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sparkJobDefinitionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state sparkJobDefinitionResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID // Preserve the existing ID
+
+	if !plan.DisplayName.Equal(state.DisplayName) || !plan.Description.Equal(state.Description) {
+		url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/sparkJobDefinitions/%s", state.WorkspaceID.ValueString(), state.ID.ValueString())
+		body := map[string]interface{}{
+			"displayName": plan.DisplayName.ValueString(),
+			"description": plan.Description.ValueString(),
+		}
+		if _, err := r.client.Patch(url, body); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Spark job definition",
+				"Could not update Spark job definition: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	files, err := sparkJobDefinitionFiles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Spark job definition files",
+			"Could not read local Spark job definition files: "+err.Error(),
+		)
+		return
+	}
+	plan.FilesHash = types.StringValue(hashDefinitionParts(files))
+
+	if !plan.FilesHash.Equal(state.FilesHash) {
+		if err := r.uploadFiles(plan, files); err != nil {
+			resp.Diagnostics.AddError(
+				"Error uploading Spark job definition files",
+				"Could not upload files to OneLake: "+err.Error(),
+			)
+			return
+		}
+
+		// Remove files that are no longer part of the job. The previous local files are often gone by now,
+		// so their OneLake paths are derived from the names in the state.
+		planned := make(map[string]struct{}, len(files))
+		for _, file := range files {
+			planned[file.Path] = struct{}{}
+		}
+		for _, filePath := range sparkJobDefinitionFilePaths(state) {
+			if _, ok := planned[filePath]; ok {
+				continue
+			}
+			oneLakePath := fmt.Sprintf("%s/%s/%s", state.WorkspaceID.ValueString(), state.ID.ValueString(), filePath)
+			if err := r.client.DeleteOneLakePath(oneLakePath, false); err != nil {
+				resp.Diagnostics.AddWarning(
+					"Error removing Spark job definition file",
+					fmt.Sprintf("Could not remove %s from OneLake: %s", filePath, err.Error()),
+				)
+			}
+		}
+	}
+
+	if err := r.updateDefinition(plan, files); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Spark job definition",
+			"Could not update Spark job definition: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *sparkJobDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sparkJobDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the item also removes its files from OneLake.
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/sparkJobDefinitions/%s", state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err := r.client.Delete(url); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Spark job definition",
+			"Could not delete Spark job definition: "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// sparkJobDefinitionFiles reads the main and reference files. The part path is the target path inside the item.
+func sparkJobDefinitionFiles(model sparkJobDefinitionResourceModel) ([]definitionPart, error) {
+	mainFile, err := readDefinitionFile(model.MainFilePath.ValueString(), sparkJobDefinitionMainFilePath(model.MainFilePath.ValueString()))
+	if err != nil {
+		return nil, err
+	}
+	files := []definitionPart{mainFile}
+
+	seen := make(map[string]struct{})
+	for _, referencePath := range model.ReferenceFilePaths {
+		partPath := sparkJobDefinitionReferenceFilePath(referencePath.ValueString())
+		if _, exists := seen[partPath]; exists {
+			return nil, fmt.Errorf("duplicate reference file name: %s", filepath.Base(referencePath.ValueString()))
+		}
+		seen[partPath] = struct{}{}
+
+		referenceFile, err := readDefinitionFile(referencePath.ValueString(), partPath)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, referenceFile)
+	}
+
+	return files, nil
+}
+
+// sparkJobDefinitionFilePaths returns the paths inside the item of the files of a model without reading them.
+func sparkJobDefinitionFilePaths(model sparkJobDefinitionResourceModel) []string {
+	paths := []string{sparkJobDefinitionMainFilePath(model.MainFilePath.ValueString())}
+	for _, referencePath := range model.ReferenceFilePaths {
+		paths = append(paths, sparkJobDefinitionReferenceFilePath(referencePath.ValueString()))
+	}
+	return paths
+}
+
+// sparkJobDefinitionMainFilePath returns the path inside the item the main file is uploaded to.
+func sparkJobDefinitionMainFilePath(localPath string) string {
+	return "Main/" + filepath.Base(localPath)
+}
+
+// sparkJobDefinitionReferenceFilePath returns the path inside the item a reference file is uploaded to.
+func sparkJobDefinitionReferenceFilePath(localPath string) string {
+	return "Libs/" + filepath.Base(localPath)
+}
+
+// setSparkJobDefinitionFromV1 copies the job settings of an exported definition into the model.
+func setSparkJobDefinitionFromV1(model *sparkJobDefinitionResourceModel, definition sparkJobDefinitionV1) {
+	optionalString := func(current types.String, value string) types.String {
+		if value == "" && current.IsNull() {
+			return types.StringNull()
+		}
+		return types.StringValue(value)
+	}
+
+	model.Language = types.StringValue(definition.Language)
+	model.MainClass = optionalString(model.MainClass, definition.MainClass)
+	model.CommandLineArguments = optionalString(model.CommandLineArguments, definition.CommandLineArguments)
+	model.DefaultLakehouseID = optionalString(model.DefaultLakehouseID, definition.DefaultLakehouseArtifactID)
+
+	environmentID := ""
+	if definition.EnvironmentArtifactID != nil {
+		environmentID = *definition.EnvironmentArtifactID
+	}
+	model.EnvironmentID = optionalString(model.EnvironmentID, environmentID)
+
+	if len(definition.AdditionalLakehouseIDs) > 0 || model.AdditionalLakehouseIDs != nil {
+		lakehouseIDs := make([]types.String, 0, len(definition.AdditionalLakehouseIDs))
+		for _, lakehouseID := range definition.AdditionalLakehouseIDs {
+			lakehouseIDs = append(lakehouseIDs, types.StringValue(lakehouseID))
+		}
+		model.AdditionalLakehouseIDs = lakehouseIDs
+	}
+
+	// A different main file means the job was changed outside of Terraform; force a new upload.
+	expectedMainFile := apiclient.OneLakeABFSSURL(model.WorkspaceID.ValueString(), model.ID.ValueString(), sparkJobDefinitionMainFilePath(model.MainFilePath.ValueString()))
+	if definition.ExecutableFile != expectedMainFile {
+		model.FilesHash = types.StringNull()
+	}
+}
+
+// uploadFiles uploads the main and reference files into the OneLake folder of the item.
+func (r *sparkJobDefinitionResource) uploadFiles(model sparkJobDefinitionResourceModel, files []definitionPart) error {
+	for _, file := range files {
+		oneLakePath := fmt.Sprintf("%s/%s/%s", model.WorkspaceID.ValueString(), model.ID.ValueString(), file.Path)
+		if err := r.client.UploadOneLakeFile(oneLakePath, file.Payload); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// updateDefinition writes SparkJobDefinitionV1.json pointing to the uploaded files.
+func (r *sparkJobDefinitionResource) updateDefinition(model sparkJobDefinitionResourceModel, files []definitionPart) error {
+	workspaceID := model.WorkspaceID.ValueString()
+	itemID := model.ID.ValueString()
+
+	definition := sparkJobDefinitionV1{
+		ExecutableFile:             apiclient.OneLakeABFSSURL(workspaceID, itemID, files[0].Path),
+		DefaultLakehouseArtifactID: model.DefaultLakehouseID.ValueString(),
+		MainClass:                  model.MainClass.ValueString(),
+		AdditionalLakehouseIDs:     []string{},
+		CommandLineArguments:       model.CommandLineArguments.ValueString(),
+		AdditionalLibraryURIs:      []string{},
+		Language:                   model.Language.ValueString(),
+	}
+
+	for _, lakehouseID := range model.AdditionalLakehouseIDs {
+		definition.AdditionalLakehouseIDs = append(definition.AdditionalLakehouseIDs, lakehouseID.ValueString())
+	}
+	for _, file := range files[1:] {
+		definition.AdditionalLibraryURIs = append(definition.AdditionalLibraryURIs, apiclient.OneLakeABFSSURL(workspaceID, itemID, file.Path))
+	}
+	if !model.EnvironmentID.IsNull() {
+		environmentID := model.EnvironmentID.ValueString()
+		definition.EnvironmentArtifactID = &environmentID
+	}
+
+	payload, err := json.Marshal(definition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SparkJobDefinitionV1.json: %w", err)
+	}
+
+	parts := []definitionPart{{Path: "SparkJobDefinitionV1.json", Payload: payload}}
+	return updateItemDefinition(r.client, workspaceID, itemID, sparkJobDefinitionFormat, parts)
+}
+
+// Helper function to create the Spark job definition item.
+func (r *sparkJobDefinitionResource) createSparkJobDefinition(workspaceID, displayName, description string) (string, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/sparkJobDefinitions", workspaceID)
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+	}
+
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to make POST request: %w", err)
+	}
+
+	sparkJobDefinitionID, ok := responseBody["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected response format: 'id' key not found")
+	}
+
+	return sparkJobDefinitionID, nil
+}
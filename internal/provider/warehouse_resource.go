@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Collations supported by the warehouse creation payload.
+const (
+	warehouseCaseSensitiveCollation   = "Latin1_General_100_BIN2_UTF8"
+	warehouseCaseInsensitiveCollation = "Latin1_General_100_CI_AS_KS_WS_SC_UTF8"
+)
+
+// Define the resource struct.
+type warehouseResource struct {
+	client *apiclient.APIClient
+}
+
+// Define the schema for the warehouse resource.
+func (r *warehouseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for the warehouse resource.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the workspace where the warehouse will be created.",
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The display name for the warehouse resource. It can be changed in place.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional description of the warehouse resource. It can be changed in place.",
+			},
+			"case_insensitive_collation": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Create the warehouse with the case-insensitive collation Latin1_General_100_CI_AS_KS_WS_SC_UTF8 instead of the default case-sensitive collation. Changing this value recreates the warehouse.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"collation_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The collation of the warehouse as reported by Fabric.",
+			},
+			"connection_string": schema.StringAttribute{
+				Computed:    true,
+				Description: "Connection string for the SQL endpoint of the warehouse.",
+			},
+			"created_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time the warehouse was created.",
+			},
+			"last_updated_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time of the last update of the warehouse as reported by Fabric.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "The timestamp of the last update made to the warehouse resource by Terraform.",
+			},
+		},
+	}
+}
+
+// Define the model for the warehouse resource.
+type warehouseResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	WorkspaceID              types.String `tfsdk:"workspace_id"`
+	DisplayName              types.String `tfsdk:"display_name"`
+	Description              types.String `tfsdk:"description"`
+	CaseInsensitiveCollation types.Bool   `tfsdk:"case_insensitive_collation"`
+	CollationType            types.String `tfsdk:"collation_type"`
+	ConnectionString         types.String `tfsdk:"connection_string"`
+	CreatedDate              types.String `tfsdk:"created_date"`
+	LastUpdatedTime          types.String `tfsdk:"last_updated_time"`
+	LastUpdated              types.String `tfsdk:"last_updated"`
+}
+
+// Implement Metadata method.
+func (r *warehouseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_warehouse"
+}
+
+// Create a new instance of warehouseResource.
+func NewWarehouseResource(client *apiclient.APIClient) resource.Resource {
+	return &warehouseResource{client: client}
+}
+
+// Implement CRUD operations.
+func (r *warehouseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from the plan.
+	var plan warehouseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create warehouse and wait for the provisioning to finish.
+	warehouseID, err := r.createWarehouse(plan.WorkspaceID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString(), plan.CaseInsensitiveCollation.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating warehouse",
+			"Could not create warehouse: "+err.Error(),
+		)
+		return
+	}
+
+	// Read back the newly created warehouse to get all known attributes.
+	createdWarehouse, err := r.readWarehouse(plan.WorkspaceID.ValueString(), warehouseID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading newly created warehouse",
+			"Could not read warehouse: "+err.Error(),
+		)
+		return
+	}
+
+	// Set ID and LastUpdated fields.
+	plan.ID = types.StringValue(warehouseID)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	setWarehouseProperties(&plan, createdWarehouse)
+
+	// Set state.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *warehouseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Retrieve ID from state.
+	var state warehouseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read warehouse from API.
+	warehouse, err := r.readWarehouse(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading warehouse",
+			"Could not read warehouse: "+err.Error(),
+		)
+		return
+	}
+
+	displayName, ok := warehouse["displayName"].(string)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error reading warehouse",
+			"Unexpected response format: 'displayName' key not found or not a string",
+		)
+		return
+	}
+
+	description, _ := warehouse["description"].(string) // defaults to empty string if not found
+
+	// Set state with the response values.
+	state.DisplayName = types.StringValue(displayName)
+	if !state.Description.IsNull() || description != "" {
+		state.Description = types.StringValue(description)
+	}
+	setWarehouseProperties(&state, warehouse)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *warehouseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from the plan.
+	var plan warehouseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve ID from state.
+	var state warehouseResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rename the warehouse or change its description in place.
+	err := r.updateWarehouse(state.WorkspaceID.ValueString(), state.ID.ValueString(), plan.DisplayName.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating warehouse",
+			"Could not update warehouse: "+err.Error(),
+		)
+		return
+	}
+
+	// Read back the updated warehouse.
+	updatedWarehouse, err := r.readWarehouse(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading updated warehouse",
+			"Could not read warehouse: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID // Ensure the ID remains unchanged.
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	setWarehouseProperties(&plan, updatedWarehouse)
+
+	// Set state.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *warehouseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve ID from state.
+	var state warehouseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete warehouse.
+	err := r.deleteWarehouse(state.WorkspaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting warehouse",
+			"Could not delete warehouse: "+err.Error(),
+		)
+		return
+	}
+
+	// Remove resource from state.
+	resp.State.RemoveResource(ctx)
+}
+
+// setWarehouseProperties copies the computed warehouse properties from an API response into the model.
+func setWarehouseProperties(model *warehouseResourceModel, warehouse map[string]interface{}) {
+	properties, _ := warehouse["properties"].(map[string]interface{})
+
+	connectionString, _ := getMapString("connectionString", properties)
+	createdDate, _ := getMapString("createdDate", properties)
+	lastUpdatedTime, _ := getMapString("lastUpdatedTime", properties)
+	collationType, _ := getMapString("collationType", properties)
+
+	model.ConnectionString = types.StringValue(connectionString)
+	model.CreatedDate = types.StringValue(createdDate)
+	model.LastUpdatedTime = types.StringValue(lastUpdatedTime)
+	model.CollationType = types.StringValue(collationType)
+}
+
+// Helper functions for warehouse operations.
+func (r *warehouseResource) createWarehouse(workspaceID, displayName, description string, caseInsensitive bool) (string, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/warehouses", workspaceID)
+
+	collation := warehouseCaseSensitiveCollation
+	if caseInsensitive {
+		collation = warehouseCaseInsensitiveCollation
+	}
+
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+		"creationPayload": map[string]interface{}{
+			"defaultCollation": collation,
+		},
+	}
+
+	// Warehouse creation is a long-running operation; the result contains the created item.
+	responseBody, err := r.client.PostWithLongRunningOperation(url, body)
+	if err != nil {
+		return "", fmt.Errorf("error during POST request: %w", err)
+	}
+
+	warehouseID, ok := responseBody["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected response format: 'id' key not found")
+	}
+
+	return warehouseID, nil
+}
+
+func (r *warehouseResource) readWarehouse(workspaceID, warehouseID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/warehouses/%s", workspaceID, warehouseID)
+	return r.client.Get(url)
+}
+
+func (r *warehouseResource) updateWarehouse(workspaceID, warehouseID, displayName, description string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/warehouses/%s", workspaceID, warehouseID)
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"description": description,
+	}
+
+	_, err := r.client.Patch(url, body)
+	return err
+}
+
+func (r *warehouseResource) deleteWarehouse(workspaceID, warehouseID string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/warehouses/%s", workspaceID, warehouseID)
+	return r.client.Delete(url)
+}
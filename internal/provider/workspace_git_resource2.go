@@ -2,17 +2,26 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"terraform-provider-microsoftfabric/internal/apiclient"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &workspaceGitResource{}
+)
+
 // Define the combined resource.
 type workspaceGitResource struct {
 	client *apiclient.APIClient
@@ -20,13 +29,17 @@ type workspaceGitResource struct {
 
 // Define the schema for the combined resource.
 func (r *workspaceGitResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Only the branch can be switched on an existing connection.
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
 			"workspace_id": schema.StringAttribute{
-				Required: true,
+				Required:      true,
+				PlanModifiers: requiresReplace,
 			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
@@ -35,22 +48,54 @@ func (r *workspaceGitResource) Schema(_ context.Context, _ resource.SchemaReques
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"organization_name": schema.StringAttribute{
-						Required: true,
+						Optional:      true,
+						Description:   "The Azure DevOps organization. Required for AzureDevOps.",
+						PlanModifiers: requiresReplace,
 					},
 					"project_name": schema.StringAttribute{
-						Required: true,
+						Optional:      true,
+						Description:   "The Azure DevOps project. Required for AzureDevOps.",
+						PlanModifiers: requiresReplace,
+					},
+					"owner_name": schema.StringAttribute{
+						Optional:      true,
+						Description:   "The owner of the GitHub repository, a user or an organization. Required for GitHub.",
+						PlanModifiers: requiresReplace,
+					},
+					"custom_domain_name": schema.StringAttribute{
+						Optional:      true,
+						Description:   "The custom domain of a GitHub Enterprise repository, e.g. 'github.contoso.com'.",
+						PlanModifiers: requiresReplace,
 					},
 					"git_provider_type": schema.StringAttribute{
-						Required: true,
+						Required:      true,
+						Description:   "The Git provider: 'AzureDevOps' or 'GitHub'.",
+						PlanModifiers: requiresReplace,
 					},
 					"repository_name": schema.StringAttribute{
-						Required: true,
+						Required:      true,
+						PlanModifiers: requiresReplace,
 					},
 					"branch_name": schema.StringAttribute{
 						Required: true,
 					},
 					"directory_name": schema.StringAttribute{
-						Required: true,
+						Required:      true,
+						PlanModifiers: requiresReplace,
+					},
+				},
+			},
+			"my_git_credentials": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The Git credentials of the caller, set through git/myGitCredentials. Service principals and GitHub connections need a configured connection.",
+				Attributes: map[string]schema.Attribute{
+					"source": schema.StringAttribute{
+						Required:    true,
+						Description: "The source of the credentials: 'Automatic' uses the caller's SSO, 'ConfiguredConnection' uses a cloud connection.",
+					},
+					"connection_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ID of the cloud connection. Required for ConfiguredConnection.",
 					},
 				},
 			},
@@ -69,6 +114,7 @@ type workspaceGitResourceModel struct {
 	ID                     types.String            `tfsdk:"id"`
 	WorkspaceID            types.String            `tfsdk:"workspace_id"`
 	GitProviderDetails     GitProviderDetailsModel `tfsdk:"git_provider_details"`
+	MyGitCredentials       *GitCredentialsModel    `tfsdk:"my_git_credentials"`
 	LastUpdated            types.String            `tfsdk:"last_updated"`
 	InitializationStrategy types.String            `tfsdk:"initialization_strategy"`
 	RemoteCommitHash       types.String            `tfsdk:"remote_commit_hash"`
@@ -77,12 +123,19 @@ type workspaceGitResourceModel struct {
 type GitProviderDetailsModel struct {
 	OrganizationName types.String `tfsdk:"organization_name"`
 	ProjectName      types.String `tfsdk:"project_name"`
+	OwnerName        types.String `tfsdk:"owner_name"`
+	CustomDomainName types.String `tfsdk:"custom_domain_name"`
 	GitProviderType  types.String `tfsdk:"git_provider_type"`
 	RepositoryName   types.String `tfsdk:"repository_name"`
 	BranchName       types.String `tfsdk:"branch_name"`
 	DirectoryName    types.String `tfsdk:"directory_name"`
 }
 
+type GitCredentialsModel struct {
+	Source       types.String `tfsdk:"source"`
+	ConnectionID types.String `tfsdk:"connection_id"`
+}
+
 // Implement Metadata method.
 func (r *workspaceGitResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "microsoftfabric_workspace_git"
@@ -93,6 +146,91 @@ func NewWorkspaceGitResource(client *apiclient.APIClient) resource.Resource {
 	return &workspaceGitResource{client: client}
 }
 
+// ValidateConfig checks that the provider details and credentials match the Git provider type.
+func (r *workspaceGitResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config workspaceGitResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	details := config.GitProviderDetails
+	detailsPath := path.Root("git_provider_details")
+	requireDetail := func(value types.String, name, providerType string) {
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				detailsPath.AtName(name),
+				"Missing Git provider detail",
+				fmt.Sprintf("%s is required for git_provider_type %q.", name, providerType),
+			)
+		}
+	}
+	rejectDetail := func(value types.String, name, providerType string) {
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				detailsPath.AtName(name),
+				"Unsupported Git provider detail",
+				fmt.Sprintf("%s is not supported for git_provider_type %q.", name, providerType),
+			)
+		}
+	}
+
+	if !details.GitProviderType.IsUnknown() {
+		switch providerType := details.GitProviderType.ValueString(); providerType {
+		case "AzureDevOps":
+			requireDetail(details.OrganizationName, "organization_name", providerType)
+			requireDetail(details.ProjectName, "project_name", providerType)
+			rejectDetail(details.OwnerName, "owner_name", providerType)
+			rejectDetail(details.CustomDomainName, "custom_domain_name", providerType)
+		case "GitHub":
+			requireDetail(details.OwnerName, "owner_name", providerType)
+			rejectDetail(details.OrganizationName, "organization_name", providerType)
+			rejectDetail(details.ProjectName, "project_name", providerType)
+			if config.MyGitCredentials == nil || (!config.MyGitCredentials.Source.IsUnknown() && config.MyGitCredentials.Source.ValueString() != "ConfiguredConnection") {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("my_git_credentials"),
+					"Missing Git credentials",
+					"GitHub connections need my_git_credentials with source 'ConfiguredConnection'.",
+				)
+			}
+		default:
+			resp.Diagnostics.AddAttributeError(
+				detailsPath.AtName("git_provider_type"),
+				"Invalid Git provider type",
+				fmt.Sprintf("Unsupported git_provider_type %q. Available options are 'AzureDevOps' and 'GitHub'.", providerType),
+			)
+		}
+	}
+
+	if credentials := config.MyGitCredentials; credentials != nil && !credentials.Source.IsUnknown() {
+		switch credentials.Source.ValueString() {
+		case "Automatic":
+			if !credentials.ConnectionID.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("my_git_credentials").AtName("connection_id"),
+					"Unsupported connection ID",
+					"connection_id can only be set with source 'ConfiguredConnection'.",
+				)
+			}
+		case "ConfiguredConnection":
+			if credentials.ConnectionID.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("my_git_credentials").AtName("connection_id"),
+					"Missing connection ID",
+					"connection_id is required with source 'ConfiguredConnection'.",
+				)
+			}
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("my_git_credentials").AtName("source"),
+				"Invalid Git credentials source",
+				fmt.Sprintf("Unsupported source %q. Available options are 'Automatic' and 'ConfiguredConnection'.", credentials.Source.ValueString()),
+			)
+		}
+	}
+}
+
 // Implement CRUD operations.
 func (r *workspaceGitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
@@ -103,38 +241,54 @@ func (r *workspaceGitResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// Connect workspace to Git.
-	err := r.connectWorkspaceToGit(plan.WorkspaceID.ValueString(), plan.GitProviderDetails)
+	workspaceID := plan.WorkspaceID.ValueString()
+	plan.ID = types.StringValue(workspaceID)
+
+	// A connection left behind by an earlier failed apply is replaced.
+	connectionState, err := r.readGitConnectionState(workspaceID)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error connecting workspace to Git",
-			"Could not connect workspace to Git: "+err.Error(),
+			"Error reading Git connection",
+			gitErrorDetail("Could not read Git connection", err),
 		)
 		return
 	}
+	if connectionState != "NotConnected" {
+		err = r.deleteGitConnection(workspaceID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting Git connection",
+				gitErrorDetail("Could not remove the previous Git connection", err),
+			)
+			return
+		}
+	}
 
-	// Create Git connection.
-	remoteCommitHash, err := r.createGitInit(plan.WorkspaceID.ValueString(), plan.InitializationStrategy.ValueString())
+	// Connect workspace to Git.
+	err = r.connectWorkspaceToGit(workspaceID, plan.GitProviderDetails, plan.MyGitCredentials)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating git connection",
-			"Could not create git connection: "+err.Error(),
+			"Error connecting workspace to Git",
+			gitErrorDetail("Could not connect workspace to Git", err),
 		)
 		return
 	}
 
-	// Commit from Git.
-	err = r.commitFromGit(remoteCommitHash, plan.WorkspaceID.ValueString())
+	// Configure the caller's Git credentials, initialize the connection and run the required sync.
+	remoteCommitHash, err := r.initializeGitConnection(workspaceID, plan.InitializationStrategy.ValueString(), plan.MyGitCredentials)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error committing from Git",
-			"Could not commit from Git: "+err.Error(),
+			"Error initializing Git connection",
+			gitErrorDetail("Could not initialize Git connection", err),
 		)
+		// The workspace is connected; the next refresh sees it is not initialized and plans a new connection.
+		plan.RemoteCommitHash = types.StringValue("")
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 		return
 	}
 
 	// Set fields.
-	plan.ID = types.StringValue(plan.WorkspaceID.ValueString())
 	plan.RemoteCommitHash = types.StringValue(remoteCommitHash)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -178,14 +332,39 @@ func (r *workspaceGitResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	// Update state based on the response
+	// Update state based on the response; the details depend on the Git provider type.
+	optionalDetail := func(key string) types.String {
+		if value, ok := getMapString(key, gitProviderDetails); ok && value != "" {
+			return types.StringValue(value)
+		}
+		return types.StringNull()
+	}
+	requiredDetail := func(key string) types.String {
+		value, _ := getMapString(key, gitProviderDetails)
+		return types.StringValue(value)
+	}
 	state.GitProviderDetails = GitProviderDetailsModel{
-		OrganizationName: types.StringValue(gitProviderDetails["organizationName"].(string)),
-		ProjectName:      types.StringValue(gitProviderDetails["projectName"].(string)),
-		GitProviderType:  types.StringValue(gitProviderDetails["gitProviderType"].(string)),
-		RepositoryName:   types.StringValue(gitProviderDetails["repositoryName"].(string)),
-		BranchName:       types.StringValue(gitProviderDetails["branchName"].(string)),
-		DirectoryName:    types.StringValue(gitProviderDetails["directoryName"].(string)),
+		OrganizationName: optionalDetail("organizationName"),
+		ProjectName:      optionalDetail("projectName"),
+		OwnerName:        optionalDetail("ownerName"),
+		CustomDomainName: optionalDetail("customDomainName"),
+		GitProviderType:  requiredDetail("gitProviderType"),
+		RepositoryName:   requiredDetail("repositoryName"),
+		BranchName:       requiredDetail("branchName"),
+		DirectoryName:    requiredDetail("directoryName"),
+	}
+
+	// Refresh the caller's credentials when they are managed.
+	if state.MyGitCredentials != nil {
+		credentials, err := r.readMyGitCredentials(state.WorkspaceID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Git credentials",
+				fmt.Sprintf("Could not read Git credentials for workspace ID %s: %s", state.WorkspaceID.ValueString(), err.Error()),
+			)
+			return
+		}
+		state.MyGitCredentials = credentials
 	}
 
 	if gitSyncDetails, ok := respBody["gitSyncDetails"].(map[string]interface{}); ok {
@@ -236,54 +415,66 @@ func (r *workspaceGitResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	// Step 1: Delete the existing Git connection.
-	err := r.deleteGitConnection(state.WorkspaceID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting Git connection",
-			"Could not delete Git connection: "+err.Error(),
-		)
+	workspaceID := state.WorkspaceID.ValueString()
+	plan.ID = state.ID // Ensure the ID remains unchanged.
+
+	// Repository and directory changes require replacement, and a new initialization strategy only
+	// applies to the next connection. That leaves credentials and the branch to update in place.
+	if plan.GitProviderDetails.BranchName.Equal(state.GitProviderDetails.BranchName) {
+		if plan.MyGitCredentials != nil && !gitCredentialsEqual(plan.MyGitCredentials, state.MyGitCredentials) {
+			err := r.updateMyGitCredentials(workspaceID, *plan.MyGitCredentials)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error configuring Git credentials",
+					gitErrorDetail("Could not configure Git credentials", err),
+				)
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+				return
+			}
+		}
+
+		plan.RemoteCommitHash = state.RemoteCommitHash
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	// Step 2: Reconnect to Git.
-	err = r.connectWorkspaceToGit(plan.WorkspaceID.ValueString(), plan.GitProviderDetails)
+	// Switch the branch: Fabric binds a connection to one branch, so the workspace is reconnected and
+	// synced with the new branch as the initialization strategy requires.
+	err := r.deleteGitConnection(workspaceID)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error connecting workspace to Git",
-			"Could not connect workspace to Git: "+err.Error(),
+			"Error switching Git branch",
+			gitErrorDetail("Could not disconnect the workspace from the current branch", err),
 		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 		return
 	}
 
-	// Step 3: Create Git initialization.
-	remoteCommitHash, err := r.createGitInit(plan.WorkspaceID.ValueString(), plan.InitializationStrategy.ValueString())
+	err = r.connectWorkspaceToGit(workspaceID, plan.GitProviderDetails, plan.MyGitCredentials)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating git connection",
-			"Could not create git connection: "+err.Error(),
+			"Error switching Git branch",
+			gitErrorDetail("Could not connect the workspace to branch "+plan.GitProviderDetails.BranchName.ValueString(), err),
 		)
+		// The workspace is no longer connected, so the connection has to be created again.
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Step 4: Commit from Git if there's a valid remote commit hash.
-	if plan.RemoteCommitHash.ValueString() != "" {
-		err = r.commitFromGit(plan.RemoteCommitHash.ValueString(), state.WorkspaceID.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error committing from Git",
-				"Could not commit from Git: "+err.Error(),
-			)
-			return
-		}
-	}
-
-	// Step 5: Set LastUpdated field and other state attributes.
-	plan.ID = state.ID // Ensure the ID remains unchanged.
+	remoteCommitHash, err := r.initializeGitConnection(workspaceID, plan.InitializationStrategy.ValueString(), plan.MyGitCredentials)
 	plan.RemoteCommitHash = types.StringValue(remoteCommitHash)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error switching Git branch",
+			gitErrorDetail("Could not initialize the connection to branch "+plan.GitProviderDetails.BranchName.ValueString(), err),
+		)
+	}
 
-	// Set state.
+	// Set state; after a failed initialization the next refresh plans a new connection.
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -299,10 +490,10 @@ func (r *workspaceGitResource) Delete(ctx context.Context, req resource.DeleteRe
 
 	// Delete Git connection.
 	err := r.deleteGitConnection(state.WorkspaceID.ValueString())
-	if err != nil {
+	if err != nil && !isGitNotConnectedError(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting Git connection",
-			"Could not delete Git connection: "+err.Error(),
+			gitErrorDetail("Could not delete Git connection", err),
 		)
 		return
 	}
@@ -312,85 +503,218 @@ func (r *workspaceGitResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 // Helper function to connect workspace to Git.
-func (r *workspaceGitResource) connectWorkspaceToGit(workspaceID string, details GitProviderDetailsModel) error {
+func (r *workspaceGitResource) connectWorkspaceToGit(workspaceID string, details GitProviderDetailsModel, credentials *GitCredentialsModel) error {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/connect", workspaceID)
 
 	// Prepare the request body.
+	providerDetails := map[string]interface{}{
+		"gitProviderType": details.GitProviderType.ValueString(),
+		"repositoryName":  details.RepositoryName.ValueString(),
+		"branchName":      details.BranchName.ValueString(),
+		"directoryName":   details.DirectoryName.ValueString(),
+	}
+	if details.GitProviderType.ValueString() == "GitHub" {
+		providerDetails["ownerName"] = details.OwnerName.ValueString()
+		if !details.CustomDomainName.IsNull() {
+			providerDetails["customDomainName"] = details.CustomDomainName.ValueString()
+		}
+	} else {
+		providerDetails["organizationName"] = details.OrganizationName.ValueString()
+		providerDetails["projectName"] = details.ProjectName.ValueString()
+	}
+
 	body := map[string]interface{}{
-		"gitProviderDetails": map[string]interface{}{
-			"organizationName": details.OrganizationName.ValueString(),
-			"projectName":      details.ProjectName.ValueString(),
-			"gitProviderType":  details.GitProviderType.ValueString(),
-			"repositoryName":   details.RepositoryName.ValueString(),
-			"branchName":       details.BranchName.ValueString(),
-			"directoryName":    details.DirectoryName.ValueString(),
-		},
+		"gitProviderDetails": providerDetails,
+	}
+
+	// A configured connection is needed to connect without the caller's SSO, e.g. for GitHub.
+	if credentials != nil && credentials.Source.ValueString() == "ConfiguredConnection" {
+		body["myGitCredentials"] = gitCredentialsBody(*credentials)
 	}
 
-	bodyBytes, err := json.Marshal(body)
+	_, err := r.client.PostWithLongRunningOperation(url, body)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %v", err)
+		return fmt.Errorf("failed to connect workspace to Git: %w", err)
 	}
 
-	_, err = r.client.PostBytes(url, bodyBytes)
+	return nil
+}
+
+// Helper function to configure the caller's Git credentials.
+func (r *workspaceGitResource) updateMyGitCredentials(workspaceID string, credentials GitCredentialsModel) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/myGitCredentials", workspaceID)
+
+	_, err := r.client.Patch(url, gitCredentialsBody(credentials))
 	if err != nil {
-		return fmt.Errorf("failed to connect workspace to Git: %v", err)
+		return fmt.Errorf("failed to update Git credentials: %w", err)
 	}
 
 	return nil
 }
 
-// Helper function to create Git initialization.
-func (r *workspaceGitResource) createGitInit(workspaceID, initializationStrategy string) (string, error) {
-	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/initializeConnection", workspaceID)
+// Helper function to read the caller's Git credentials.
+func (r *workspaceGitResource) readMyGitCredentials(workspaceID string) (*GitCredentialsModel, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/myGitCredentials", workspaceID)
 
+	respBody, err := r.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	source, _ := getMapString("source", respBody)
+	credentials := &GitCredentialsModel{
+		Source:       types.StringValue(source),
+		ConnectionID: types.StringNull(),
+	}
+	if connectionID, ok := getMapString("connectionId", respBody); ok && connectionID != "" {
+		credentials.ConnectionID = types.StringValue(connectionID)
+	}
+
+	return credentials, nil
+}
+
+// gitCredentialsBody builds the myGitCredentials request body.
+func gitCredentialsBody(credentials GitCredentialsModel) map[string]interface{} {
+	body := map[string]interface{}{
+		"source": credentials.Source.ValueString(),
+	}
+	if credentials.Source.ValueString() == "ConfiguredConnection" {
+		body["connectionId"] = credentials.ConnectionID.ValueString()
+	}
+	return body
+}
+
+// gitCredentialsEqual reports whether two credential blocks configure the same credentials.
+func gitCredentialsEqual(a, b *GitCredentialsModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Source.Equal(b.Source) && a.ConnectionID.Equal(b.ConnectionID)
+}
+
+// Helper function to read the state of the Git connection, e.g. 'NotConnected' or 'ConnectedAndInitialized'.
+func (r *workspaceGitResource) readGitConnectionState(workspaceID string) (string, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/connection", workspaceID)
+
+	respBody, err := r.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+
+	connectionState, _ := getMapString("gitConnectionState", respBody)
+	return connectionState, nil
+}
+
+// Helper function to initialize a new Git connection. It configures the caller's credentials, runs
+// the sync that the initialization requires and returns the commit the workspace is synced with.
+func (r *workspaceGitResource) initializeGitConnection(workspaceID, initializationStrategy string, credentials *GitCredentialsModel) (string, error) {
+	if credentials != nil {
+		if err := r.updateMyGitCredentials(workspaceID, *credentials); err != nil {
+			return "", err
+		}
+	}
+
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/initializeConnection", workspaceID)
 	requestBody := map[string]interface{}{
 		"initializationStrategy": initializationStrategy,
 	}
 
-	respBody, err := r.client.Post(url, requestBody)
+	respBody, err := r.client.PostWithLongRunningOperation(url, requestBody)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to initialize Git connection: %w", err)
 	}
 
-	// Log the API response for debugging.
-	fmt.Printf("API Response: %+v\n", respBody)
+	requiredAction, _ := getMapString("requiredAction", respBody)
+	workspaceHead, _ := getMapString("workspaceHead", respBody)
+	remoteCommitHash, _ := getMapString("remoteCommitHash", respBody)
 
-	// Check for the expected field in the response.
-	remoteCommitHash, exists := respBody["remoteCommitHash"].(string)
-	if !exists || remoteCommitHash == "" {
-		return "", fmt.Errorf("expected field 'remoteCommitHash' not found in response or is empty")
+	switch requiredAction {
+	case "UpdateFromGit":
+		err = r.updateFromGit(workspaceID, workspaceHead, remoteCommitHash)
+	case "CommitToGit":
+		err = r.commitToGit(workspaceID, workspaceHead)
+	}
+	if err != nil {
+		return remoteCommitHash, err
 	}
 
-	return remoteCommitHash, nil
-}
+	// The connection reports the commit the workspace is synced with after the required action.
+	url = fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/connection", workspaceID)
+	connection, err := r.client.Get(url)
+	if err != nil {
+		return remoteCommitHash, err
+	}
+	if gitSyncDetails, ok := connection["gitSyncDetails"].(map[string]interface{}); ok {
+		if head, ok := getMapString("head", gitSyncDetails); ok && head != "" {
+			return head, nil
+		}
+	}
 
-// Helper function for updating the Git connection.
-func (r *workspaceGitResource) updateGitConnection(workspaceID string, details GitProviderDetailsModel) error {
-	// Implement logic to update the current Git connection if needed.
-	return r.connectWorkspaceToGit(workspaceID, details) // Reconnect for simplicity.
+	return remoteCommitHash, nil
 }
 
 // Helper function to delete Git connection.
 func (r *workspaceGitResource) deleteGitConnection(workspaceID string) error {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/disconnect", workspaceID)
 
-	r.client.Post(url, nil)
-	// Since the delete operation doesn't return a JSON body, we don't need to handle any response body here.
+	// The disconnect operation doesn't return a JSON body.
+	_, err := r.client.PostWithLongRunningOperation(url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to disconnect workspace from Git: %w", err)
+	}
+
 	return nil
 }
 
-// Helper function to commit from Git.
-func (r *workspaceGitResource) commitFromGit(remoteCommitHash string, workspaceID string) error {
+// Helper function to update the workspace from Git.
+func (r *workspaceGitResource) updateFromGit(workspaceID, workspaceHead, remoteCommitHash string) error {
 	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/updateFromGit", workspaceID)
 	body := map[string]interface{}{
 		"remoteCommitHash": remoteCommitHash,
 	}
+	if workspaceHead != "" {
+		body["workspaceHead"] = workspaceHead
+	}
 
-	_, err := r.client.Post(url, body)
+	_, err := r.client.PostWithLongRunningOperation(url, body)
 	if err != nil {
-		return fmt.Errorf("failed to commit from Git: %v", err)
+		return fmt.Errorf("failed to update workspace from Git: %w", err)
 	}
 
 	return nil
 }
+
+// Helper function to commit the workspace content to Git.
+func (r *workspaceGitResource) commitToGit(workspaceID, workspaceHead string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/commitToGit", workspaceID)
+	body := map[string]interface{}{
+		"mode":          "All",
+		"workspaceHead": workspaceHead,
+		"comment":       "Initial commit of the workspace content",
+	}
+
+	_, err := r.client.PostWithLongRunningOperation(url, body)
+	if err != nil {
+		return fmt.Errorf("failed to commit workspace to Git: %w", err)
+	}
+
+	return nil
+}
+
+// isGitNotConnectedError reports whether the workspace was not connected to Git in the first place.
+func isGitNotConnectedError(err error) bool {
+	var fabricErr *apiclient.FabricError
+	if errors.As(err, &fabricErr) {
+		return fabricErr.ErrorCode == "WorkspaceNotConnectedToGit" || fabricErr.StatusCode == http.StatusNotFound
+	}
+	return isNotFoundError(err)
+}
+
+// gitErrorDetail formats an error, leading with the Fabric error code when the service returned one.
+func gitErrorDetail(message string, err error) string {
+	var fabricErr *apiclient.FabricError
+	if errors.As(err, &fabricErr) && fabricErr.ErrorCode != "" {
+		return fmt.Sprintf("%s [%s]: %s", message, fabricErr.ErrorCode, err.Error())
+	}
+	return message + ": " + err.Error()
+}
@@ -0,0 +1,463 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-microsoftfabric/internal/apiclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &workspaceGitSyncResource{}
+	_ resource.ResourceWithModifyPlan     = &workspaceGitSyncResource{}
+)
+
+// gitSyncChangeType is the object type of a pending change reported by git/status.
+var gitSyncChangeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"item_type":        types.StringType,
+		"display_name":     types.StringType,
+		"object_id":        types.StringType,
+		"workspace_change": types.StringType,
+		"remote_change":    types.StringType,
+		"conflict_type":    types.StringType,
+	},
+}
+
+// workspaceGitSyncResource keeps a Git connected workspace in sync with its branch. Read exposes the
+// pending changes; when there are changes in the configured direction, the next apply syncs them.
+type workspaceGitSyncResource struct {
+	client *apiclient.APIClient
+}
+
+// Schema defines the schema for the workspace Git sync resource.
+func (r *workspaceGitSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of a workspace that is connected to Git.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"direction": schema.StringAttribute{
+				Required:    true,
+				Description: "The direction of the sync: 'UpdateFromGit' updates the workspace with remote commits, 'CommitToGit' commits workspace changes to the branch.",
+			},
+			"conflict_resolution": schema.StringAttribute{
+				Optional:    true,
+				Description: "How conflicts are resolved when updating from Git: 'PreferRemote' or 'PreferWorkspace'. Without a policy an update with conflicts fails.",
+			},
+			"allow_override_items": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow updating items in the workspace that were changed there, when updating from Git.",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "The commit message used when committing to Git.",
+			},
+			"workspace_head": schema.StringAttribute{
+				Computed:    true,
+				Description: "The commit the workspace was last synced with.",
+			},
+			"remote_commit_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "The latest commit of the connected branch.",
+			},
+			"pending_changes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The items that differ between the workspace and the branch.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"item_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the item.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the item.",
+						},
+						"object_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the item in the workspace, empty for items that only exist in Git.",
+						},
+						"workspace_change": schema.StringAttribute{
+							Computed:    true,
+							Description: "The change in the workspace: 'Added', 'Modified', 'Deleted' or 'None'.",
+						},
+						"remote_change": schema.StringAttribute{
+							Computed:    true,
+							Description: "The change in Git: 'Added', 'Modified', 'Deleted' or 'None'.",
+						},
+						"conflict_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Whether the item has a conflict: 'None', 'Conflict' or 'SameChanges'.",
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// workspaceGitSyncResourceModel defines the model of the workspace Git sync resource.
+type workspaceGitSyncResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	WorkspaceID        types.String `tfsdk:"workspace_id"`
+	Direction          types.String `tfsdk:"direction"`
+	ConflictResolution types.String `tfsdk:"conflict_resolution"`
+	AllowOverrideItems types.Bool   `tfsdk:"allow_override_items"`
+	Comment            types.String `tfsdk:"comment"`
+	WorkspaceHead      types.String `tfsdk:"workspace_head"`
+	RemoteCommitHash   types.String `tfsdk:"remote_commit_hash"`
+	PendingChanges     types.List   `tfsdk:"pending_changes"`
+	LastUpdated        types.String `tfsdk:"last_updated"`
+}
+
+type gitSyncChangeModel struct {
+	ItemType        types.String `tfsdk:"item_type"`
+	DisplayName     types.String `tfsdk:"display_name"`
+	ObjectID        types.String `tfsdk:"object_id"`
+	WorkspaceChange types.String `tfsdk:"workspace_change"`
+	RemoteChange    types.String `tfsdk:"remote_change"`
+	ConflictType    types.String `tfsdk:"conflict_type"`
+}
+
+// gitStatus is the answer of git/status.
+type gitStatus struct {
+	WorkspaceHead    string
+	RemoteCommitHash string
+	Changes          []gitSyncChangeModel
+}
+
+// Metadata sets the resource type name.
+func (r *workspaceGitSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "microsoftfabric_workspace_git_sync"
+}
+
+// NewWorkspaceGitSyncResource creates a new workspace Git sync resource.
+func NewWorkspaceGitSyncResource(client *apiclient.APIClient) resource.Resource {
+	return &workspaceGitSyncResource{client: client}
+}
+
+// ValidateConfig checks the direction and conflict resolution policy.
+func (r *workspaceGitSyncResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config workspaceGitSyncResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Direction.IsUnknown() {
+		switch config.Direction.ValueString() {
+		case "UpdateFromGit", "CommitToGit":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("direction"),
+				"Invalid direction",
+				fmt.Sprintf("Unsupported direction %q. Available options are 'UpdateFromGit' and 'CommitToGit'.", config.Direction.ValueString()),
+			)
+		}
+	}
+
+	if !config.ConflictResolution.IsNull() && !config.ConflictResolution.IsUnknown() {
+		switch config.ConflictResolution.ValueString() {
+		case "PreferRemote", "PreferWorkspace":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("conflict_resolution"),
+				"Invalid conflict resolution",
+				fmt.Sprintf("Unsupported conflict resolution %q. Available options are 'PreferRemote' and 'PreferWorkspace'.", config.ConflictResolution.ValueString()),
+			)
+		}
+	}
+}
+
+// ModifyPlan plans a sync when the last read found changes in the configured direction.
+func (r *workspaceGitSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state workspaceGitSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Direction.IsUnknown() || state.PendingChanges.IsNull() || state.PendingChanges.IsUnknown() {
+		return
+	}
+
+	var changes []gitSyncChangeModel
+	resp.Diagnostics.Append(state.PendingChanges.ElementsAs(ctx, &changes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !hasGitSyncChanges(changes, plan.Direction.ValueString()) {
+		return
+	}
+
+	plan.WorkspaceHead = types.StringUnknown()
+	plan.RemoteCommitHash = types.StringUnknown()
+	plan.PendingChanges = types.ListUnknown(gitSyncChangeType)
+	plan.LastUpdated = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create syncs the workspace and records the resulting status.
+func (r *workspaceGitSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workspaceGitSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.WorkspaceID.ValueString())
+	resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Git status of the workspace.
+func (r *workspaceGitSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state workspaceGitSyncResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := r.getGitStatus(state.WorkspaceID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading Git status",
+			fmt.Sprintf("Could not read Git status of workspace %s: %s", state.WorkspaceID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setGitSyncStatus(ctx, &state, status)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update syncs the workspace with the updated settings.
+func (r *workspaceGitSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan workspaceGitSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete stops syncing; the workspace stays connected to Git.
+func (r *workspaceGitSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+// sync runs updateFromGit or commitToGit when the workspace has changes in the configured direction
+// and stores the status after the sync in the model.
+func (r *workspaceGitSyncResource) sync(ctx context.Context, model *workspaceGitSyncResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	workspaceID := model.WorkspaceID.ValueString()
+
+	status, err := r.getGitStatus(workspaceID)
+	if err != nil {
+		diags.AddError(
+			"Error reading Git status",
+			fmt.Sprintf("Could not read Git status of workspace %s: %s", workspaceID, err.Error()),
+		)
+		return diags
+	}
+
+	if hasGitSyncChanges(status.Changes, model.Direction.ValueString()) {
+		if model.Direction.ValueString() == "CommitToGit" {
+			err = r.commitToGit(workspaceID, status, model.Comment.ValueString())
+		} else {
+			err = r.updateFromGit(workspaceID, status, model.ConflictResolution.ValueString(), model.AllowOverrideItems)
+		}
+		if err != nil {
+			diags.AddError(
+				"Error syncing workspace with Git",
+				fmt.Sprintf("Could not run %s for workspace %s: %s", model.Direction.ValueString(), workspaceID, err.Error()),
+			)
+			return diags
+		}
+
+		status, err = r.getGitStatus(workspaceID)
+		if err != nil {
+			diags.AddError(
+				"Error reading Git status",
+				fmt.Sprintf("Could not read Git status of workspace %s: %s", workspaceID, err.Error()),
+			)
+			return diags
+		}
+	}
+
+	model.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	diags.Append(setGitSyncStatus(ctx, model, status)...)
+	return diags
+}
+
+// setGitSyncStatus copies the Git status into the model.
+func setGitSyncStatus(ctx context.Context, model *workspaceGitSyncResourceModel, status gitStatus) diag.Diagnostics {
+	model.WorkspaceHead = types.StringValue(status.WorkspaceHead)
+	model.RemoteCommitHash = types.StringValue(status.RemoteCommitHash)
+
+	changes := status.Changes
+	if changes == nil {
+		changes = []gitSyncChangeModel{}
+	}
+
+	var diags diag.Diagnostics
+	model.PendingChanges, diags = types.ListValueFrom(ctx, gitSyncChangeType, changes)
+	return diags
+}
+
+// getGitStatus calls git/status, which may run as a long-running operation.
+func (r *workspaceGitSyncResource) getGitStatus(workspaceID string) (gitStatus, error) {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/status", workspaceID)
+
+	respBody, err := r.client.GetWithLongRunningOperation(url)
+	if err != nil {
+		return gitStatus{}, err
+	}
+
+	var status gitStatus
+	status.WorkspaceHead, _ = getMapString("workspaceHead", respBody)
+	status.RemoteCommitHash, _ = getMapString("remoteCommitHash", respBody)
+
+	changes, _ := respBody["changes"].([]interface{})
+	for _, item := range changes {
+		change, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		itemMetadata, _ := change["itemMetadata"].(map[string]interface{})
+		itemIdentifier, _ := itemMetadata["itemIdentifier"].(map[string]interface{})
+
+		itemType, _ := getMapString("itemType", itemMetadata)
+		displayName, _ := getMapString("displayName", itemMetadata)
+		objectID, _ := getMapString("objectId", itemIdentifier)
+		workspaceChange, _ := getMapString("workspaceChange", change)
+		remoteChange, _ := getMapString("remoteChange", change)
+		conflictType, _ := getMapString("conflictType", change)
+
+		status.Changes = append(status.Changes, gitSyncChangeModel{
+			ItemType:        types.StringValue(itemType),
+			DisplayName:     types.StringValue(displayName),
+			ObjectID:        types.StringValue(objectID),
+			WorkspaceChange: types.StringValue(workspaceChange),
+			RemoteChange:    types.StringValue(remoteChange),
+			ConflictType:    types.StringValue(conflictType),
+		})
+	}
+
+	return status, nil
+}
+
+// updateFromGit updates the workspace with the remote commit and waits for the operation.
+func (r *workspaceGitSyncResource) updateFromGit(workspaceID string, status gitStatus, conflictResolution string, allowOverrideItems types.Bool) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/updateFromGit", workspaceID)
+
+	body := map[string]interface{}{
+		"remoteCommitHash": status.RemoteCommitHash,
+	}
+	if status.WorkspaceHead != "" {
+		body["workspaceHead"] = status.WorkspaceHead
+	}
+	if conflictResolution != "" {
+		body["conflictResolution"] = map[string]interface{}{
+			"conflictResolutionType":   "Workspace",
+			"conflictResolutionPolicy": conflictResolution,
+		}
+	}
+	if !allowOverrideItems.IsNull() {
+		body["options"] = map[string]interface{}{
+			"allowOverrideItems": allowOverrideItems.ValueBool(),
+		}
+	}
+
+	_, err := r.client.PostWithLongRunningOperation(url, body)
+	return err
+}
+
+// commitToGit commits all workspace changes to the branch and waits for the operation.
+func (r *workspaceGitSyncResource) commitToGit(workspaceID string, status gitStatus, comment string) error {
+	url := fmt.Sprintf("https://api.fabric.microsoft.com/v1/workspaces/%s/git/commitToGit", workspaceID)
+
+	body := map[string]interface{}{
+		"mode":          "All",
+		"workspaceHead": status.WorkspaceHead,
+	}
+	if comment != "" {
+		body["comment"] = comment
+	}
+
+	_, err := r.client.PostWithLongRunningOperation(url, body)
+	return err
+}
+
+// hasGitSyncChanges reports whether the changes require a sync in the given direction. git/status omits
+// workspaceChange or remoteChange when that side did not change, so an empty value counts as "None".
+func hasGitSyncChanges(changes []gitSyncChangeModel, direction string) bool {
+	changed := func(value types.String) bool {
+		return value.ValueString() != "" && value.ValueString() != "None"
+	}
+	for _, change := range changes {
+		if direction == "CommitToGit" {
+			if changed(change.WorkspaceChange) {
+				return true
+			}
+			continue
+		}
+		if changed(change.RemoteChange) {
+			return true
+		}
+	}
+	return false
+}